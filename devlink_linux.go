@@ -2,12 +2,19 @@ package mlxdevm
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 
 	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
 	"golang.org/x/sys/unix"
 )
 
@@ -18,32 +25,400 @@ type DevlinkDevEswitchAttr struct {
 	EncapMode  string
 }
 
+// DevlinkDevReloadStats represents how many times a reload action was
+// performed with a given limit
+type DevlinkDevReloadStats struct {
+	Action uint8
+	Limit  uint8
+	Value  uint32
+}
+
 // DevlinkDevAttrs represents device attributes
 type DevlinkDevAttrs struct {
-	Eswitch DevlinkDevEswitchAttr
+	Eswitch           DevlinkDevEswitchAttr
+	ReloadFailed      bool
+	ReloadStats       []DevlinkDevReloadStats
+	RemoteReloadStats []DevlinkDevReloadStats
+}
+
+// DevlinkDevReloadAttrs represents attributes of a device reload request
+type DevlinkDevReloadAttrs struct {
+	Action        uint8
+	Limit         uint8
+	NetnsFd       uint32
+	NetnsPid      uint32
+	NetnsId       int32
+	ActionValid   bool
+	LimitValid    bool
+	NetnsFdValid  bool
+	NetnsPidValid bool
+	NetnsIdValid  bool
 }
 
-// DevlinkDevice represents device and its attributes
+// DevlinkDevice represents device and its attributes.
+// NestedDevlinks lists the devlink instances nested in this device, e.g. the
+// instances of its provisioned line cards.
 type DevlinkDevice struct {
+	BusName        string
+	DeviceName     string
+	Attrs          DevlinkDevAttrs
+	NestedDevlinks []DevlinkNestedDevice
+}
+
+// DevlinkDeviceInfoVersion represents a single named version of a device component
+type DevlinkDeviceInfoVersion struct {
+	Name  string
+	Value string
+}
+
+// DevlinkDeviceInfo represents device information reported by the driver
+type DevlinkDeviceInfo struct {
+	BusName           string
+	DeviceName        string
+	Driver            string
+	SerialNumber      string
+	BoardSerialNumber string
+	FixedVersions     []DevlinkDeviceInfoVersion
+	RunningVersions   []DevlinkDeviceInfoVersion
+	StoredVersions    []DevlinkDeviceInfoVersion
+}
+
+// DevlinkFlashUpdateAttrs represents attributes of a flash update request
+type DevlinkFlashUpdateAttrs struct {
+	FileName      string
+	Component     string
+	OverwriteMask uint32
+}
+
+// DevlinkFlashStatus represents a flash update progress notification
+type DevlinkFlashStatus struct {
+	Message   string
+	Component string
+	Done      uint64
+	Total     uint64
+	Timeout   uint64
+}
+
+// DevlinkHealthReporter represents a device or port health reporter
+type DevlinkHealthReporter struct {
+	BusName        string
+	DeviceName     string
+	PortIndex      uint32
+	PortIndexValid bool
+	Name           string
+	State          uint8
+	ErrorCount     uint64
+	RecoverCount   uint64
+	GracePeriod    uint64
+	AutoRecover    bool
+	AutoDump       bool
+	DumpTs         uint64
+	DumpTsNs       uint64
+}
+
+// DevlinkHealthReporterAttrs identifies a device health reporter, or a port
+// health reporter when PortIndexValid is set
+type DevlinkHealthReporterAttrs struct {
+	Name           string
+	PortIndex      uint32
+	PortIndexValid bool
+}
+
+// DevlinkHealthReporterSetAttrs represents health reporter attributes to set
+type DevlinkHealthReporterSetAttrs struct {
+	GracePeriod      uint64
+	AutoRecover      bool
+	AutoDump         bool
+	GracePeriodValid bool
+	AutoRecoverValid bool
+	AutoDumpValid    bool
+}
+
+// DevlinkFmsgPair represents a named value of a formatted message object.
+// Value is one of uint8, uint16, uint32, uint64, bool, string, []byte,
+// DevlinkFmsgObject or []any holding any of these.
+type DevlinkFmsgPair struct {
+	Name  string
+	Value any
+}
+
+// DevlinkFmsgObject represents a formatted message object as reported by
+// health diagnose and dump, keeping the pair order of the kernel
+type DevlinkFmsgObject []DevlinkFmsgPair
+
+// DevlinkRegion represents a device or port memory region and its snapshots
+type DevlinkRegion struct {
+	BusName        string
+	DeviceName     string
+	PortIndex      uint32
+	PortIndexValid bool
+	Name           string
+	Size           uint64
+	MaxSnapshots   uint32
+	Snapshots      []uint32
+}
+
+// DevlinkRegionAttrs identifies a device region, or a port region when
+// PortIndexValid is set
+type DevlinkRegionAttrs struct {
+	Name           string
+	PortIndex      uint32
+	PortIndexValid bool
+}
+
+// DevlinkRegionReadAttrs represents attributes of a region read request.
+// A zero Length reads the whole region.
+type DevlinkRegionReadAttrs struct {
+	SnapshotID uint32
+	Direct     bool
+	Address    uint64
+	Length     uint64
+}
+
+// DevlinkRate represents a port function leaf rate or a rate node.
+// Rates are expressed in bytes per second.
+type DevlinkRate struct {
+	BusName    string
+	DeviceName string
+	Type       uint16
+	PortIndex  uint32
+	NodeName   string
+	TxShare    uint64
+	TxMax      uint64
+	TxPriority uint32
+	TxWeight   uint32
+	Parent     string
+}
+
+// DevlinkRateSetAttrs represents rate attributes to set. An empty Parent
+// detaches the rate object from its parent node.
+type DevlinkRateSetAttrs struct {
+	TxShare         uint64
+	TxMax           uint64
+	TxPriority      uint32
+	TxWeight        uint32
+	Parent          string
+	TxShareValid    bool
+	TxMaxValid      bool
+	TxPriorityValid bool
+	TxWeightValid   bool
+	ParentValid     bool
+}
+
+// DevlinkTrapStats represents packet trap statistics
+type DevlinkTrapStats struct {
+	RxPackets uint64
+	RxBytes   uint64
+	RxDropped uint64
+}
+
+// DevlinkTrap represents a packet trap and its attributes
+type DevlinkTrap struct {
+	BusName    string
+	DeviceName string
+	Name       string
+	Group      string
+	Type       string
+	Action     string
+	Generic    bool
+	Metadata   []string
+	Stats      DevlinkTrapStats
+}
+
+// DevlinkTrapGroup represents a packet trap group and its attributes
+type DevlinkTrapGroup struct {
+	BusName        string
+	DeviceName     string
+	Name           string
+	Generic        bool
+	PolicerID      uint32
+	PolicerIDValid bool
+	Stats          DevlinkTrapStats
+}
+
+// DevlinkTrapGroupSetAttrs represents trap group attributes to set.
+// An empty Action leaves the action of the group traps unchanged and
+// a zero PolicerID unbinds the group from its policer.
+type DevlinkTrapGroupSetAttrs struct {
+	Action         string
+	PolicerID      uint32
+	PolicerIDValid bool
+}
+
+// DevlinkTrapPolicer represents a packet trap policer and its attributes
+type DevlinkTrapPolicer struct {
+	BusName    string
+	DeviceName string
+	ID         uint32
+	Rate       uint64
+	Burst      uint64
+	Stats      DevlinkTrapStats
+}
+
+// DevlinkTrapPolicerSetAttrs represents trap policer attributes to set
+type DevlinkTrapPolicerSetAttrs struct {
+	Rate       uint64
+	Burst      uint64
+	RateValid  bool
+	BurstValid bool
+}
+
+// DevlinkSb represents a shared buffer of a devlink device
+type DevlinkSb struct {
+	BusName          string
+	DeviceName       string
+	Index            uint32
+	Size             uint32
+	IngressPoolCount uint16
+	EgressPoolCount  uint16
+	IngressTcCount   uint16
+	EgressTcCount    uint16
+}
+
+// DevlinkSbPool represents a shared buffer pool and its attributes
+type DevlinkSbPool struct {
+	BusName       string
+	DeviceName    string
+	SbIndex       uint32
+	Index         uint16
+	Type          string
+	Size          uint32
+	ThresholdType string
+	CellSize      uint32
+}
+
+// DevlinkSbPortPool represents the threshold and occupancy of a shared
+// buffer pool for a devlink port
+type DevlinkSbPortPool struct {
+	BusName    string
+	DeviceName string
+	PortIndex  uint32
+	SbIndex    uint32
+	PoolIndex  uint16
+	Threshold  uint32
+	OccCur     uint32
+	OccMax     uint32
+}
+
+// DevlinkSbTcBind represents the binding of a devlink port traffic class
+// to a shared buffer pool
+type DevlinkSbTcBind struct {
 	BusName    string
 	DeviceName string
-	Attrs      DevlinkDevAttrs
+	PortIndex  uint32
+	SbIndex    uint32
+	TcIndex    uint16
+	Type       string
+	PoolIndex  uint16
+	Threshold  uint32
+	OccCur     uint32
+	OccMax     uint32
+}
+
+// DevlinkNestedDevice identifies a devlink instance nested in another devlink
+// object, e.g. the device of a provisioned line card or of an active SF. NetnsID is reported
+// only when the nested instance is in another network namespace.
+type DevlinkNestedDevice struct {
+	BusName      string
+	DeviceName   string
+	NetnsID      int32
+	NetnsIDValid bool
+}
+
+// DevlinkLinecard represents a line card slot and its attributes
+type DevlinkLinecard struct {
+	BusName        string
+	DeviceName     string
+	Index          uint32
+	State          string
+	Type           string
+	SupportedTypes []string
+	Nested         *DevlinkNestedDevice
+}
+
+// DevlinkDpipeFieldRef represents a dpipe table match or action on a header field
+type DevlinkDpipeFieldRef struct {
+	Type         uint32
+	HeaderID     uint32
+	HeaderGlobal bool
+	HeaderIndex  uint32
+	FieldID      uint32
+}
+
+// DevlinkDpipeTable represents a dpipe table. ResourceID refers to the
+// DevlinkResource the table entries are accounted to when ResourceValid is set.
+type DevlinkDpipeTable struct {
+	Name            string
+	Size            uint64
+	CountersEnabled bool
+	ResourceID      uint64
+	ResourceUnits   uint64
+	ResourceValid   bool
+	Matches         []DevlinkDpipeFieldRef
+	Actions         []DevlinkDpipeFieldRef
+}
+
+// DevlinkDpipeValue represents the value of a dpipe entry match or action
+type DevlinkDpipeValue struct {
+	Field        DevlinkDpipeFieldRef
+	Value        []byte
+	Mask         []byte
+	Mapping      uint32
+	MappingValid bool
+}
+
+// DevlinkDpipeEntry represents a dpipe table entry
+type DevlinkDpipeEntry struct {
+	Index        uint64
+	MatchValues  []DevlinkDpipeValue
+	ActionValues []DevlinkDpipeValue
+	Counter      uint64
+	CounterValid bool
+}
+
+// DevlinkDpipeField represents a field of a dpipe header
+type DevlinkDpipeField struct {
+	Name        string
+	ID          uint32
+	Bitwidth    uint32
+	MappingType uint32
+}
+
+// DevlinkDpipeHeader represents a dpipe header and its fields
+type DevlinkDpipeHeader struct {
+	Name   string
+	ID     uint32
+	Global bool
+	Fields []DevlinkDpipeField
 }
 
-// DevlinkPortFn represents port function and its attributes
+// DevlinkPortFn represents port function and its attributes.
+// Migratable, IpsecCrypto, IpsecPacket, MaxIOEqs and Devlink are only reported
+// by netlink family 'devlink'. Devlink is the devlink instance of an active SF.
 type DevlinkPortFn struct {
-	HwAddr  net.HardwareAddr
-	State   uint8
-	OpState uint8
-	Trust   uint8
+	HwAddr      net.HardwareAddr
+	State       uint8
+	OpState     uint8
+	Trust       uint8
+	Roce        bool
+	Migratable  bool
+	IpsecCrypto bool
+	IpsecPacket bool
+	MaxIOEqs    uint32
+	Devlink     *DevlinkNestedDevice
 }
 
 // DevlinkPortFnSetAttrs represents attributes to set
 type DevlinkPortFnSetAttrs struct {
-	FnAttrs     DevlinkPortFn
-	HwAddrValid bool
-	StateValid  bool
-	TrustValid  bool
+	FnAttrs          DevlinkPortFn
+	HwAddrValid      bool
+	StateValid       bool
+	TrustValid       bool
+	RoceValid        bool
+	MigratableValid  bool
+	IpsecCryptoValid bool
+	IpsecPacketValid bool
+	MaxIOEqsValid    bool
 }
 
 // DevlinkPortFnCap represents port function and its attributes
@@ -52,11 +427,13 @@ type DevlinkPortFnCap struct {
 	UCList uint32
 }
 
-// DevlinkPortFnCapSetAttrs represents attributes to set
+// DevlinkPortFnCapSetAttrs represents attributes to set. When Verify is
+// set, the capabilities are read back after the set and compared.
 type DevlinkPortFnCapSetAttrs struct {
 	FnCapAttrs  DevlinkPortFnCap
 	RoceValid   bool
 	UCListValid bool
+	Verify      bool
 }
 
 // DevlinkDevParam represents a device parameter
@@ -66,21 +443,73 @@ type DevlinkDevParam struct {
 	CMode     uint8
 }
 
-// DevlinkPort represents port and its attributes
-type DevlinkPort struct {
+// DevlinkDevParamValue represents the value of a device parameter in one
+// configuration mode. Data holds the raw value of the MNL_TYPE_* Type, a
+// flag value is reported as a single 0 or 1 byte.
+type DevlinkDevParamValue struct {
+	CMode uint8
+	Type  uint8
+	Data  []byte
+}
+
+// DevlinkDevParamInfo represents a device or port parameter with the values
+// of all its supported configuration modes
+type DevlinkDevParamInfo struct {
 	BusName        string
 	DeviceName     string
 	PortIndex      uint32
-	PortType       uint16
-	NetdeviceName  string
-	NetdevIfIndex  uint32
-	RdmaDeviceName string
-	PortFlavour    uint16
-	Controller     uint32
-	PfNumber       uint16
-	SfNumber       uint32
-	Fn             *DevlinkPortFn
-	PortCap        *DevlinkPortFnCap
+	PortIndexValid bool
+	Name           string
+	Generic        bool
+	Type           uint8
+	Values         []DevlinkDevParamValue
+}
+
+// PortType represents the type of a devlink port
+type PortType uint16
+
+// PortFlavour represents the flavour of a devlink port
+type PortFlavour uint16
+
+// DevlinkPort represents port and its attributes
+type DevlinkPort struct {
+	BusName         string
+	DeviceName      string
+	PortIndex       uint32
+	PortType        PortType
+	NetdeviceName   string
+	NetdevIfIndex   uint32
+	RdmaDeviceName  string
+	PortFlavour     PortFlavour
+	Controller      uint32
+	PfNumber        uint16
+	VfNumber        uint16
+	SfNumber        uint32
+	External        bool
+	PortNumber      uint32
+	Lanes           uint32
+	Splittable      bool
+	SplitGroup      uint32
+	SplitSubport    uint32
+	Split           bool
+	Fn              *DevlinkPortFn
+	PortCap         *DevlinkPortFnCap
+	ControllerValid bool
+	PfNumberValid   bool
+	SfNumberValid   bool
+}
+
+// DevlinkPortFilter selects ports by the fields whose Valid flag is set.
+// A port matches a PF number, SF number or controller only if it reports it.
+type DevlinkPortFilter struct {
+	Flavour         PortFlavour
+	PfNumber        uint16
+	SfNumber        uint32
+	Controller      uint32
+	FlavourValid    bool
+	PfNumberValid   bool
+	SfNumberValid   bool
+	ControllerValid bool
 }
 
 type DevlinkPortAddAttrs struct {
@@ -192,7 +621,7 @@ func (dlr *DevlinkResource) parseAttributes(attrs map[uint16]syscall.NetlinkRout
 		}
 
 		for _, subresource := range subResources {
-			resource := DevlinkResource{Parent: dlr}
+			resource := DevlinkResource{}
 			attrs, err := nl.ParseRouteAttrAsMap(subresource.Value)
 			if err != nil {
 				return err
@@ -257,9 +686,23 @@ func (dlrs *DevlinkResources) parseAttributes(attrs map[uint16]syscall.NetlinkRo
 		dlrs.Resources = append(dlrs.Resources, resource)
 	}
 
+	// link the parents only once all the children slices are final
+	for i := range dlrs.Resources {
+		dlrs.Resources[i].linkChildren()
+	}
+
 	return nil
 }
 
+// linkChildren points the Parent of all resources in the subtree to their
+// parent element in the Children slice
+func (dlr *DevlinkResource) linkChildren() {
+	for i := range dlr.Children {
+		dlr.Children[i].Parent = dlr
+		dlr.Children[i].linkChildren()
+	}
+}
+
 func parseDevlinkDeviceList(msgs [][]byte) ([]*DevlinkDevice, error) {
 	devices := make([]*DevlinkDevice, 0, len(msgs))
 	for _, m := range msgs {
@@ -286,6 +729,32 @@ func eswitchStringToMode(modeName string) (uint16, error) {
 	}
 }
 
+func eswitchStringToInlineMode(inlineModeName string) (uint8, error) {
+	switch inlineModeName {
+	case "none":
+		return DEVLINK_ESWITCH_INLINE_MODE_NONE, nil
+	case "link":
+		return DEVLINK_ESWITCH_INLINE_MODE_LINK, nil
+	case "network":
+		return DEVLINK_ESWITCH_INLINE_MODE_NETWORK, nil
+	case "transport":
+		return DEVLINK_ESWITCH_INLINE_MODE_TRANSPORT, nil
+	default:
+		return 0xff, fmt.Errorf("invalid eswitch inline mode")
+	}
+}
+
+func eswitchStringToEncapMode(encapModeName string) (uint8, error) {
+	switch encapModeName {
+	case "disable":
+		return DEVLINK_ESWITCH_ENCAP_MODE_NONE, nil
+	case "enable":
+		return DEVLINK_ESWITCH_ENCAP_MODE_BASIC, nil
+	default:
+		return 0xff, fmt.Errorf("invalid eswitch encap mode")
+	}
+}
+
 func parseEswitchMode(mode uint16) string {
 	var eswitchMode = map[uint16]string{
 		DEVLINK_ESWITCH_MODE_LEGACY:    "legacy",
@@ -324,9 +793,83 @@ func parseEswitchEncapMode(encapmode uint8) string {
 	}
 }
 
+func parseDevlinkReloadActionStats(action uint8, data []byte) ([]DevlinkDevReloadStats, error) {
+	var stats []DevlinkDevReloadStats
+	entries, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		attrs, err := nl.ParseRouteAttr(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		stat := DevlinkDevReloadStats{Action: action}
+		for _, a := range attrs {
+			switch a.Attr.Type & nl.NLA_TYPE_MASK {
+			case DEVLINK_ATTR_RELOAD_STATS_LIMIT:
+				stat.Limit = uint8(a.Value[0])
+			case DEVLINK_ATTR_RELOAD_STATS_VALUE:
+				stat.Value = native.Uint32(a.Value)
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+func parseDevlinkReloadStats(data []byte) ([]DevlinkDevReloadStats, error) {
+	var stats []DevlinkDevReloadStats
+	infos, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		attrs, err := nl.ParseRouteAttr(info.Value)
+		if err != nil {
+			return nil, err
+		}
+		var action uint8
+		var actionStats []byte
+		for _, a := range attrs {
+			switch a.Attr.Type & nl.NLA_TYPE_MASK {
+			case DEVLINK_ATTR_RELOAD_ACTION:
+				action = uint8(a.Value[0])
+			case DEVLINK_ATTR_RELOAD_ACTION_STATS:
+				actionStats = a.Value
+			}
+		}
+		actionStat, err := parseDevlinkReloadActionStats(action, actionStats)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, actionStat...)
+	}
+	return stats, nil
+}
+
+func (d *DevlinkDevice) parseDevStats(data []byte) error {
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return err
+	}
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_RELOAD_STATS:
+			d.Attrs.ReloadStats, err = parseDevlinkReloadStats(a.Value)
+		case DEVLINK_ATTR_REMOTE_RELOAD_STATS:
+			d.Attrs.RemoteReloadStats, err = parseDevlinkReloadStats(a.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (d *DevlinkDevice) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
 	for _, a := range attrs {
-		switch a.Attr.Type {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
 		case DEVLINK_ATTR_BUS_NAME:
 			d.BusName = string(a.Value[:len(a.Value)-1])
 		case DEVLINK_ATTR_DEV_NAME:
@@ -337,6 +880,18 @@ func (d *DevlinkDevice) parseAttributes(attrs []syscall.NetlinkRouteAttr) error
 			d.Attrs.Eswitch.InlineMode = parseEswitchInlineMode(uint8(a.Value[0]))
 		case DEVLINK_ATTR_ESWITCH_ENCAP_MODE:
 			d.Attrs.Eswitch.EncapMode = parseEswitchEncapMode(uint8(a.Value[0]))
+		case DEVLINK_ATTR_RELOAD_FAILED:
+			d.Attrs.ReloadFailed = uint8(a.Value[0]) != 0
+		case DEVLINK_ATTR_DEV_STATS:
+			if err := d.parseDevStats(a.Value); err != nil {
+				return err
+			}
+		case DEVLINK_ATTR_NESTED_DEVLINK:
+			nested, err := parseDevlinkNestedDevice(a.Value)
+			if err != nil {
+				return err
+			}
+			d.NestedDevlinks = append(d.NestedDevlinks, *nested)
 		}
 	}
 	return nil
@@ -450,6 +1005,105 @@ func (h *Handle) createCmdReq(Socket string, cmd uint8, bus string, device strin
 	return f, req, nil
 }
 
+// executeDeviceDump runs a dump request for a devlink device and returns the
+// messages of that device only, as older kernels ignore the device selector
+// of a dump request.
+func (h *Handle) executeDeviceDump(Socket string, cmd uint8, Bus string, Device string) ([][]byte, error) {
+	_, req, err := h.createCmdReq(Socket, cmd, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	req.Flags |= unix.NLM_F_DUMP
+
+	msgs, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	devMsgs := make([][]byte, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttrAsMap(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		busName, busOk := attrs[DEVLINK_ATTR_BUS_NAME]
+		devName, devOk := attrs[DEVLINK_ATTR_DEV_NAME]
+		if busOk && devOk && nl.BytesToString(busName.Value) == Bus &&
+			nl.BytesToString(devName.Value) == Device {
+			devMsgs = append(devMsgs, m)
+		}
+	}
+	return devMsgs, nil
+}
+
+// executeDumpIter runs a dump request and calls f with each response message
+// as it is received, so that large dumps are not held in memory.
+func (h *Handle) executeDumpIter(req *nl.NetlinkRequest, f func(msg []byte) error) error {
+	var s *nl.NetlinkSocket
+	if sh, ok := h.sockets[unix.NETLINK_GENERIC]; ok {
+		s = sh.Socket
+		req.Seq = atomic.AddUint32(&sh.Seq, 1)
+		s.Lock()
+		defer s.Unlock()
+	} else {
+		var err error
+		s, err = nl.GetNetlinkSocketAt(h.nsHandle(), netns.None(), unix.NETLINK_GENERIC)
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+		if err := s.SetReceiveTimeout(&nl.SocketTimeoutTv); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Send(req); err != nil {
+		return err
+	}
+	pid, err := s.GetPid()
+	if err != nil {
+		return err
+	}
+
+	for {
+		msgs, from, err := s.Receive()
+		if err != nil {
+			return err
+		}
+		if from.Pid != nl.PidKernel {
+			return fmt.Errorf("wrong sender portid %d, expected %d", from.Pid, nl.PidKernel)
+		}
+		for _, m := range msgs {
+			// a shared socket may still hold replies of earlier requests
+			if m.Header.Seq != req.Seq || m.Header.Pid != pid {
+				continue
+			}
+			if m.Header.Type == unix.NLMSG_DONE || m.Header.Type == unix.NLMSG_ERROR {
+				if len(m.Data) >= 4 {
+					if errno := int32(native.Uint32(m.Data[0:4])); errno != 0 {
+						return syscall.Errno(-errno)
+					}
+				}
+				return nil
+			}
+			if err := f(m.Data); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// executeGet runs a get request and returns its single response message
+func executeGet(req *nl.NetlinkRequest) ([][]byte, error) {
+	msgs, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 1 {
+		return nil, fmt.Errorf("expected only one nl response msg")
+	}
+	return msgs, nil
+}
+
 // DevlinkGetDeviceByName provides a pointer to devlink device and nil error,
 // otherwise returns an error code.
 // Take Socket as either GENL_DEVLINK_NAME or as GENL_MLXDEVM_NAME.
@@ -506,6 +1160,56 @@ func DevlinkSetEswitchMode(Socket string, Dev *DevlinkDevice, NewMode string) er
 	return pkgHandle.DevlinkSetEswitchMode(Socket, Dev, NewMode)
 }
 
+// DevlinkSetEswitch sets any combination of eswitch mode, inline mode and
+// encap mode in a single request. Empty attributes are left unchanged.
+// It returns nil on success or error code.
+// Equivalent to: `devlink dev eswitch set $dev mode switchdev inline-mode transport encap-mode enable`
+func (h *Handle) DevlinkSetEswitch(Socket string, Dev *DevlinkDevice, Attrs DevlinkDevEswitchAttr) error {
+	if Attrs.Mode == "" && Attrs.InlineMode == "" && Attrs.EncapMode == "" {
+		return fmt.Errorf("no eswitch attribute to set")
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_ESWITCH_SET, Dev.BusName, Dev.DeviceName)
+	if err != nil {
+		return err
+	}
+
+	if Attrs.Mode != "" {
+		mode, err := eswitchStringToMode(Attrs.Mode)
+		if err != nil {
+			return err
+		}
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_ESWITCH_MODE, nl.Uint16Attr(mode)))
+	}
+
+	if Attrs.InlineMode != "" {
+		inlineMode, err := eswitchStringToInlineMode(Attrs.InlineMode)
+		if err != nil {
+			return err
+		}
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_ESWITCH_INLINE_MODE, nl.Uint8Attr(inlineMode)))
+	}
+
+	if Attrs.EncapMode != "" {
+		encapMode, err := eswitchStringToEncapMode(Attrs.EncapMode)
+		if err != nil {
+			return err
+		}
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_ESWITCH_ENCAP_MODE, nl.Uint8Attr(encapMode)))
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkSetEswitch sets any combination of eswitch mode, inline mode and
+// encap mode in a single request. Empty attributes are left unchanged.
+// It returns nil on success or error code.
+// Equivalent to: `devlink dev eswitch set $dev mode switchdev inline-mode transport encap-mode enable`
+func DevlinkSetEswitch(Socket string, Dev *DevlinkDevice, Attrs DevlinkDevEswitchAttr) error {
+	return pkgHandle.DevlinkSetEswitch(Socket, Dev, Attrs)
+}
+
 func (port *DevlinkPort) parseAttributes(Socket string, attrs []syscall.NetlinkRouteAttr) error {
 	for _, a := range attrs {
 		switch a.Attr.Type {
@@ -516,7 +1220,7 @@ func (port *DevlinkPort) parseAttributes(Socket string, attrs []syscall.NetlinkR
 		case DEVLINK_ATTR_PORT_INDEX:
 			port.PortIndex = native.Uint32(a.Value)
 		case DEVLINK_ATTR_PORT_TYPE:
-			port.PortType = native.Uint16(a.Value)
+			port.PortType = PortType(native.Uint16(a.Value))
 		case DEVLINK_ATTR_PORT_NETDEV_NAME:
 			port.NetdeviceName = string(a.Value[:len(a.Value)-1])
 		case DEVLINK_ATTR_PORT_NETDEV_IFINDEX:
@@ -524,14 +1228,34 @@ func (port *DevlinkPort) parseAttributes(Socket string, attrs []syscall.NetlinkR
 		case DEVLINK_ATTR_PORT_IBDEV_NAME:
 			port.RdmaDeviceName = string(a.Value[:len(a.Value)-1])
 		case DEVLINK_ATTR_PORT_FLAVOUR:
-			port.PortFlavour = native.Uint16(a.Value)
+			port.PortFlavour = PortFlavour(native.Uint16(a.Value))
 		case DEVLINK_ATTR_PORT_CONTROLLER_NUMBER:
 			port.Controller = native.Uint32(a.Value)
+			port.ControllerValid = true
 		case DEVLINK_ATTR_PORT_PCI_PF_NUMBER:
 			port.PfNumber = native.Uint16(a.Value)
+			port.PfNumberValid = true
+		case DEVLINK_ATTR_PORT_PCI_VF_NUMBER:
+			port.VfNumber = native.Uint16(a.Value)
 		case DEVLINK_ATTR_PORT_PCI_SF_NUMBER:
 			port.SfNumber = native.Uint32(a.Value)
+			port.SfNumberValid = true
+		case DEVLINK_ATTR_PORT_EXTERNAL:
+			port.External = uint8(a.Value[0]) != 0
+		case DEVLINK_ATTR_PORT_NUMBER:
+			port.PortNumber = native.Uint32(a.Value)
+		case DEVLINK_ATTR_PORT_LANES:
+			port.Lanes = native.Uint32(a.Value)
+		case DEVLINK_ATTR_PORT_SPLITTABLE:
+			port.Splittable = uint8(a.Value[0]) != 0
+		case DEVLINK_ATTR_PORT_SPLIT_GROUP:
+			port.SplitGroup = native.Uint32(a.Value)
+			port.Split = true
+		case DEVLINK_ATTR_PORT_SPLIT_SUBPORT_NUMBER:
+			port.SplitSubport = native.Uint32(a.Value)
 		case DEVLINK_ATTR_PORT_FUNCTION | unix.NLA_F_NESTED:
+			// keep draining the attributes on error so the parser goroutine exits
+			var fnErr error
 			for nested := range nl.ParseAttributes(a.Value) {
 				switch nested.Type {
 				case DEVLINK_PORT_FUNCTION_ATTR_HW_ADDR:
@@ -550,24 +1274,49 @@ func (port *DevlinkPort) parseAttributes(Socket string, attrs []syscall.NetlinkR
 					}
 					port.Fn.OpState = uint8(nested.Value[0])
 				case MLXDEVM_PORT_FN_ATTR_TRUST:
+					if port.Fn == nil {
+						port.Fn = &DevlinkPortFn{}
+					}
+					// 'devlink' reports the capabilities with the same attribute type
 					if Socket == GENL_MLXDEVM_NAME {
-						if port.Fn == nil {
-							port.Fn = &DevlinkPortFn{}
-						}
 						port.Fn.Trust = uint8(nested.Value[0])
+					} else {
+						port.Fn.parseCaps(native.Uint32(nested.Value))
 					}
-				case DEVLINK_PORT_FN_ATTR_EXT_CAP_ROCE:
-					if port.PortCap == nil {
-						port.PortCap = &DevlinkPortFnCap{}
+				case DEVLINK_PORT_FN_ATTR_MAX_IO_EQS:
+					if port.Fn == nil {
+						port.Fn = &DevlinkPortFn{}
+					}
+					port.Fn.MaxIOEqs = native.Uint32(nested.Value)
+				case DEVLINK_PORT_FN_ATTR_DEVLINK | unix.NLA_F_NESTED:
+					// 'mlxdevm' does not report nested devlink instances
+					if Socket == GENL_MLXDEVM_NAME {
+						continue
+					}
+					if port.Fn == nil {
+						port.Fn = &DevlinkPortFn{}
+					}
+					fnDevlink, err := parseDevlinkNestedDevice(nested.Value)
+					if err != nil {
+						fnErr = err
+						continue
+					}
+					port.Fn.Devlink = fnDevlink
+				case DEVLINK_PORT_FN_ATTR_EXT_CAP_ROCE:
+					if port.PortCap == nil {
+						port.PortCap = &DevlinkPortFnCap{}
 					}
 					port.PortCap.Roce = uint8(nested.Value[0]) != 0
 				case DEVLINK_PORT_FN_ATTR_EXT_CAP_UC_LIST:
 					if port.PortCap == nil {
 						port.PortCap = &DevlinkPortFnCap{}
 					}
-					port.PortCap.UCList = uint32(nested.Value[0])
+					port.PortCap.UCList = native.Uint32(nested.Value)
 				}
 			}
+			if fnErr != nil {
+				return fnErr
+			}
 		default:
 			continue
 		}
@@ -677,11 +1426,9 @@ func (h *Handle) DevlinkPortAdd(Socket string, Bus string, Device string, Flavou
 	if Attrs.PortIndexValid {
 		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(Attrs.PortIndex)))
 	}
-	/*
-		if Attrs.ControllerValid {
-			req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_CONTROLLER_NUMBER, nl.Uint32Attr(Attrs.Controller)))
-		}
-	*/
+	if Attrs.ControllerValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_CONTROLLER_NUMBER, nl.Uint32Attr(Attrs.Controller)))
+	}
 	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
 	if err != nil {
 		return nil, err
@@ -713,12 +1460,195 @@ func DevlinkPortDel(Socket string, Bus string, Device string, PortIndex uint32)
 	return pkgHandle.DevlinkPortDel(Socket, Bus, Device, PortIndex)
 }
 
+var portTypeNames = map[PortType]string{
+	DEVLINK_PORT_TYPE_NOTSET: "notset",
+	DEVLINK_PORT_TYPE_AUTO:   "auto",
+	DEVLINK_PORT_TYPE_ETH:    "eth",
+	DEVLINK_PORT_TYPE_IB:     "ib",
+}
+
+// String returns the port type name as shown by devlink
+func (t PortType) String() string {
+	if name, ok := portTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText returns the port type name
+func (t PortType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+var portFlavourNames = map[PortFlavour]string{
+	DEVLINK_PORT_FLAVOUR_PHYSICAL: "physical",
+	DEVLINK_PORT_FLAVOUR_CPU:      "cpu",
+	DEVLINK_PORT_FLAVOUR_DSA:      "dsa",
+	DEVLINK_PORT_FLAVOUR_PCI_PF:   "pcipf",
+	DEVLINK_PORT_FLAVOUR_PCI_VF:   "pcivf",
+	DEVLINK_PORT_FLAVOUR_VIRTUAL:  "virtual",
+	DEVLINK_PORT_FLAVOUR_UNUSED:   "unused",
+	DEVLINK_PORT_FLAVOUR_PCI_SF:   "pcisf",
+}
+
+// String returns the port flavour name as shown by devlink
+func (f PortFlavour) String() string {
+	if name, ok := portFlavourNames[f]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText returns the port flavour name
+func (f PortFlavour) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+func portStringToType(typeName string) (uint16, error) {
+	switch typeName {
+	case "auto":
+		return DEVLINK_PORT_TYPE_AUTO, nil
+	case "eth":
+		return DEVLINK_PORT_TYPE_ETH, nil
+	case "ib":
+		return DEVLINK_PORT_TYPE_IB, nil
+	default:
+		return 0xffff, fmt.Errorf("invalid port type")
+	}
+}
+
+// DevlinkPortSetType sets the type of a port to eth, ib or auto.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port set $dev/$port type eth`
+func (h *Handle) DevlinkPortSetType(Socket string, Bus string, Device string, PortIndex uint32, NewType string) error {
+	portType, err := portStringToType(NewType)
+	if err != nil {
+		return err
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_PORT_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_TYPE, nl.Uint16Attr(portType)))
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkPortSetType sets the type of a port to eth, ib or auto.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port set $dev/$port type eth`
+func DevlinkPortSetType(Socket string, Bus string, Device string, PortIndex uint32, NewType string) error {
+	return pkgHandle.DevlinkPortSetType(Socket, Bus, Device, PortIndex, NewType)
+}
+
+// DevlinkPortSplit splits a physical port into Count ports, e.g. for a
+// breakout cable. It returns nil on success or error code.
+// Equivalent to: `devlink port split $dev/$port count 4`
+func (h *Handle) DevlinkPortSplit(Socket string, Bus string, Device string, PortIndex uint32, Count uint32) error {
+	if Count < 2 {
+		return fmt.Errorf("invalid port split count %d", Count)
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_PORT_SPLIT, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_SPLIT_COUNT, nl.Uint32Attr(Count)))
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkPortSplit splits a physical port into Count ports, e.g. for a
+// breakout cable. It returns nil on success or error code.
+// Equivalent to: `devlink port split $dev/$port count 4`
+func DevlinkPortSplit(Socket string, Bus string, Device string, PortIndex uint32, Count uint32) error {
+	return pkgHandle.DevlinkPortSplit(Socket, Bus, Device, PortIndex, Count)
+}
+
+// DevlinkPortUnsplit merges the ports of a split group back into the
+// original physical port. PortIndex can be any port of the split group.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port unsplit $dev/$port`
+func (h *Handle) DevlinkPortUnsplit(Socket string, Bus string, Device string, PortIndex uint32) error {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_PORT_UNSPLIT, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkPortUnsplit merges the ports of a split group back into the
+// original physical port. PortIndex can be any port of the split group.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port unsplit $dev/$port`
+func DevlinkPortUnsplit(Socket string, Bus string, Device string, PortIndex uint32) error {
+	return pkgHandle.DevlinkPortUnsplit(Socket, Bus, Device, PortIndex)
+}
+
+func (fn *DevlinkPortFn) parseCaps(caps uint32) {
+	fn.Roce = caps&DEVLINK_PORT_FN_CAP_ROCE != 0
+	fn.Migratable = caps&DEVLINK_PORT_FN_CAP_MIGRATABLE != 0
+	fn.IpsecCrypto = caps&DEVLINK_PORT_FN_CAP_IPSEC_CRYPTO != 0
+	fn.IpsecPacket = caps&DEVLINK_PORT_FN_CAP_IPSEC_PACKET != 0
+}
+
+// capsBitfield returns the value and selector of the capabilities to set
+func (attrs *DevlinkPortFnSetAttrs) capsBitfield() (uint32, uint32) {
+	var value, selector uint32
+	caps := []struct {
+		valid bool
+		set   bool
+		bit   uint32
+	}{
+		{attrs.RoceValid, attrs.FnAttrs.Roce, DEVLINK_PORT_FN_CAP_ROCE},
+		{attrs.MigratableValid, attrs.FnAttrs.Migratable, DEVLINK_PORT_FN_CAP_MIGRATABLE},
+		{attrs.IpsecCryptoValid, attrs.FnAttrs.IpsecCrypto, DEVLINK_PORT_FN_CAP_IPSEC_CRYPTO},
+		{attrs.IpsecPacketValid, attrs.FnAttrs.IpsecPacket, DEVLINK_PORT_FN_CAP_IPSEC_PACKET},
+	}
+	for _, c := range caps {
+		if !c.valid {
+			continue
+		}
+		selector |= c.bit
+		if c.set {
+			value |= c.bit
+		}
+	}
+	return value, selector
+}
+
 // DevlinkPortFnSet sets one or more port function attributes specified by the attribute mask.
+// On netlink family 'mlxdevm' the roce capability is set with DevlinkPortFnCapSet
+// in a separate request, so it cannot be combined with other attributes.
+// The other capabilities and max_io_eqs are only supported by netlink family 'devlink'.
 // It returns 0 on success or error code.
 func (h *Handle) DevlinkPortFnSet(Socket string, Bus string, Device string, PortIndex uint32, FnAttrs DevlinkPortFnSetAttrs) error {
 	if FnAttrs.TrustValid && Socket != GENL_MLXDEVM_NAME {
 		return fmt.Errorf("setting 'trust' mode is only supported by netlink family '%s'", GENL_MLXDEVM_NAME)
 	}
+	if Socket == GENL_MLXDEVM_NAME &&
+		(FnAttrs.MigratableValid || FnAttrs.IpsecCryptoValid || FnAttrs.IpsecPacketValid || FnAttrs.MaxIOEqsValid) {
+		return fmt.Errorf("setting 'migratable', 'ipsec_crypto', 'ipsec_packet' and 'max_io_eqs' is only supported by netlink family '%s'", GENL_DEVLINK_NAME)
+	}
+
+	if Socket == GENL_MLXDEVM_NAME && FnAttrs.RoceValid {
+		if FnAttrs.HwAddrValid || FnAttrs.StateValid || FnAttrs.TrustValid {
+			return fmt.Errorf("setting 'roce' together with other attributes is not supported by netlink family '%s'", GENL_MLXDEVM_NAME)
+		}
+		capAttrs := DevlinkPortFnCapSetAttrs{
+			FnCapAttrs: DevlinkPortFnCap{Roce: FnAttrs.FnAttrs.Roce},
+			RoceValid:  true,
+		}
+		return h.DevlinkPortFnCapSet(Socket, Bus, Device, PortIndex, capAttrs)
+	}
 
 	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_PORT_SET, Bus, Device)
 	if err != nil {
@@ -741,6 +1671,15 @@ func (h *Handle) DevlinkPortFnSet(Socket string, Bus string, Device string, Port
 		fnAttr.AddRtAttr(MLXDEVM_PORT_FN_ATTR_TRUST, nl.Uint8Attr(FnAttrs.FnAttrs.Trust))
 	}
 
+	if Socket != GENL_MLXDEVM_NAME {
+		if value, selector := FnAttrs.capsBitfield(); selector != 0 {
+			fnAttr.AddRtAttr(DEVLINK_PORT_FN_ATTR_CAPS, bitfield32Attr(value, selector))
+		}
+		if FnAttrs.MaxIOEqsValid {
+			fnAttr.AddRtAttr(DEVLINK_PORT_FN_ATTR_MAX_IO_EQS, nl.Uint32Attr(FnAttrs.FnAttrs.MaxIOEqs))
+		}
+	}
+
 	req.AddData(fnAttr)
 
 	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
@@ -748,6 +1687,9 @@ func (h *Handle) DevlinkPortFnSet(Socket string, Bus string, Device string, Port
 }
 
 // DevlinkPortFnSet sets one or more port function attributes specified by the attribute mask.
+// On netlink family 'mlxdevm' the roce capability is set with DevlinkPortFnCapSet
+// in a separate request, so it cannot be combined with other attributes.
+// The other capabilities and max_io_eqs are only supported by netlink family 'devlink'.
 // It returns 0 on success or error code.
 func DevlinkPortFnSet(Socket string, Bus string, Device string, PortIndex uint32, FnAttrs DevlinkPortFnSetAttrs) error {
 	return pkgHandle.DevlinkPortFnSet(Socket, Bus, Device, PortIndex, FnAttrs)
@@ -781,7 +1723,26 @@ func (h *Handle) DevlinkPortFnCapSet(Socket string, Bus string, Device string, P
 	req.AddData(fnAttr)
 
 	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
-	return err
+	if err != nil || !FnCapAttrs.Verify {
+		return err
+	}
+
+	fnCap, err := h.DevlinkPortFnCapGet(Socket, Bus, Device, PortIndex)
+	if err != nil {
+		return err
+	}
+	return FnCapAttrs.verify(fnCap)
+}
+
+// verify checks that the valid attributes to set match the read back capabilities
+func (attrs *DevlinkPortFnCapSetAttrs) verify(fnCap *DevlinkPortFnCap) error {
+	if attrs.RoceValid && fnCap.Roce != attrs.FnCapAttrs.Roce {
+		return fmt.Errorf("port function cap roce is %t after setting %t", fnCap.Roce, attrs.FnCapAttrs.Roce)
+	}
+	if attrs.UCListValid && fnCap.UCList != attrs.FnCapAttrs.UCList {
+		return fmt.Errorf("port function cap max_uc_macs is %d after setting %d", fnCap.UCList, attrs.FnCapAttrs.UCList)
+	}
+	return nil
 }
 
 // DevlinkPortFnCapSet sets roce and max_uc_macs port function cap attributes.
@@ -792,6 +1753,27 @@ func DevlinkPortFnCapSet(Socket string, Bus string, Device string, PortIndex uin
 	return pkgHandle.DevlinkPortFnCapSet(Socket, Bus, Device, PortIndex, FnCapAttrs)
 }
 
+// DevlinkPortFnCapGet returns roce and max_uc_macs port function cap attributes,
+// otherwise returns an error code.
+// Equivalent to: `mlxdevm port function cap show $port`
+func (h *Handle) DevlinkPortFnCapGet(Socket string, Bus string, Device string, PortIndex uint32) (*DevlinkPortFnCap, error) {
+	port, err := h.DevlinkGetPortByIndex(Socket, Bus, Device, PortIndex)
+	if err != nil {
+		return nil, err
+	}
+	if port.PortCap == nil {
+		return nil, fmt.Errorf("port %d function caps are not reported", PortIndex)
+	}
+	return port.PortCap, nil
+}
+
+// DevlinkPortFnCapGet returns roce and max_uc_macs port function cap attributes,
+// otherwise returns an error code.
+// Equivalent to: `mlxdevm port function cap show $port`
+func DevlinkPortFnCapGet(Socket string, Bus string, Device string, PortIndex uint32) (*DevlinkPortFnCap, error) {
+	return pkgHandle.DevlinkPortFnCapGet(Socket, Bus, Device, PortIndex)
+}
+
 func parseDevParam(data []byte) *DevlinkDevParam {
 	param := DevlinkDevParam{}
 	var stack [][]byte
@@ -810,7 +1792,10 @@ func parseDevParam(data []byte) *DevlinkDevParam {
 			case DEVLINK_ATTR_PARAM_VALUE_CMODE:
 				param.CMode = a.Value[0]
 			case DEVLINK_ATTR_PARAM_VALUE_DATA:
-				param.Attribute.Value = a.Value
+				// the flag value is decoded from the presence of the data attribute
+				if param.Attribute.Type != MNL_TYPE_FLAG {
+					param.Attribute.Value = a.Value
+				}
 			case DEVLINK_ATTR_PARAM_VALUE | unix.NLA_F_NESTED:
 				if param.Attribute.Type == MNL_TYPE_FLAG {
 					value := 0
@@ -832,11 +1817,22 @@ func parseDevParam(data []byte) *DevlinkDevParam {
 // DevlinkDevParamGet returns information about a set device parameter
 // Equivalent to `mlxdevm dev param show $dev name disable_netdev`
 func (h *Handle) DevlinkDevParamGet(Socket string, Bus string, Device string, ParamName string) (*DevlinkDevParam, error) {
-	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_PARAM_GET, Bus, Device)
+	return h.devParamGet(Socket, Bus, Device, nil, ParamName)
+}
+
+func (h *Handle) devParamGet(Socket string, Bus string, Device string, PortIndex *uint32, ParamName string) (*DevlinkDevParam, error) {
+	cmd := uint8(DEVLINK_CMD_PARAM_GET)
+	if PortIndex != nil {
+		cmd = DEVLINK_CMD_PORT_PARAM_GET
+	}
+	_, req, err := h.createCmdReq(Socket, cmd, Bus, Device)
 	if err != nil {
 		return nil, err
 	}
 
+	if PortIndex != nil {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(*PortIndex)))
+	}
 	b := make([]byte, len(ParamName)+1)
 	copy(b, ParamName)
 	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_NAME, b))
@@ -860,6 +1856,8 @@ func cmodeStringToMode(modeName string) (uint8, error) {
 		return DEVLINK_PARAM_CMODE_RUNTIME, nil
 	} else if modeName == "driverinit" {
 		return DEVLINK_PARAM_CMODE_DRIVERINIT, nil
+	} else if modeName == "permanent" {
+		return DEVLINK_PARAM_CMODE_PERMANENT, nil
 	} else {
 		return 0xff, fmt.Errorf("invalid cmode")
 	}
@@ -869,85 +1867,186 @@ func cmodeStringToMode(modeName string) (uint8, error) {
 // It returns 0 on success or error code.
 // Equivalent to: `mlxdevm dev param set $dev name disable_netdev value true cmode runtime`
 func (h *Handle) DevlinkDevParamSet(Socket string, Bus string, Device string, ParamName string, NewValue string, NewCMode string) error {
-	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_PARAM_GET, Bus, Device)
+	setParam, err := h.DevlinkDevParamGet(Socket, Bus, Device, ParamName)
 	if err != nil {
 		return err
 	}
 
-	b := make([]byte, len(ParamName)+1)
-	copy(b, ParamName)
-	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_NAME, b))
+	paramType := uint8(setParam.Attribute.Type)
+	value, err := paramStringToValue(paramType, NewValue)
+	if err != nil {
+		return err
+	}
+	return h.devParamSet(Socket, Bus, Device, nil, ParamName, paramType, value, NewCMode)
+}
 
-	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+// DevlinkDevParamSet sets one device parameter.
+// It returns 0 on success or error code.
+// Equivalent to: `mlxdevm dev param set $dev name disable_netdev value true cmode runtime`
+func DevlinkDevParamSet(Socket string, Bus string, Device string, ParamName string, NewValue string, NewCMode string) error {
+	return pkgHandle.DevlinkDevParamSet(Socket, Bus, Device, ParamName, NewValue, NewCMode)
+}
+
+// DevlinkDevParamSetTyped sets one device parameter from a Go value: an
+// unsigned or non-negative integer for u8/u16/u32/u64 parameters, a string
+// for string parameters and a bool for flag parameters.
+// It returns nil on success or error code.
+// Equivalent to: `mlxdevm dev param set $dev name max_macs value 128 cmode driverinit`
+func (h *Handle) DevlinkDevParamSetTyped(Socket string, Bus string, Device string, ParamName string, NewValue any, NewCMode string) error {
+	setParam, err := h.DevlinkDevParamGet(Socket, Bus, Device, ParamName)
 	if err != nil {
 		return err
 	}
+	return h.devParamSet(Socket, Bus, Device, nil, ParamName, uint8(setParam.Attribute.Type), NewValue, NewCMode)
+}
 
-	setParam := parseDevParam(respmsg[0][nl.SizeofGenlmsg:])
+// DevlinkDevParamSetTyped sets one device parameter from a Go value: an
+// unsigned or non-negative integer for u8/u16/u32/u64 parameters, a string
+// for string parameters and a bool for flag parameters.
+// It returns nil on success or error code.
+// Equivalent to: `mlxdevm dev param set $dev name max_macs value 128 cmode driverinit`
+func DevlinkDevParamSetTyped(Socket string, Bus string, Device string, ParamName string, NewValue any, NewCMode string) error {
+	return pkgHandle.DevlinkDevParamSetTyped(Socket, Bus, Device, ParamName, NewValue, NewCMode)
+}
 
-	mode, err := cmodeStringToMode(NewCMode)
+func (h *Handle) devParamSet(Socket string, Bus string, Device string, PortIndex *uint32, ParamName string, paramType uint8, value any, cmode string) error {
+	mode, err := cmodeStringToMode(cmode)
 	if err != nil {
 		return err
 	}
 
-	_, req, err = h.createCmdReq(Socket, DEVLINK_CMD_PARAM_SET, Bus, Device)
+	data, err := paramValueData(paramType, value)
 	if err != nil {
 		return err
 	}
 
-	b = make([]byte, len(ParamName)+1)
-	copy(b, ParamName)
-	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_NAME, b))
+	cmd := uint8(DEVLINK_CMD_PARAM_SET)
+	if PortIndex != nil {
+		cmd = DEVLINK_CMD_PORT_PARAM_SET
+	}
+	_, req, err := h.createCmdReq(Socket, cmd, Bus, Device)
+	if err != nil {
+		return err
+	}
 
+	if PortIndex != nil {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(*PortIndex)))
+	}
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_NAME, nl.ZeroTerminated(ParamName)))
 	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_VALUE_CMODE, nl.Uint8Attr(mode)))
-	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_TYPE, nl.Uint8Attr(uint8(setParam.Attribute.Type))))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_TYPE, nl.Uint8Attr(paramType)))
+	// To pass the true flag value, we need to add an empty VALUE_DATA field
+	// for the false value, the field should not be present
+	if data != nil {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_VALUE_DATA, data))
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
 
-	switch setParam.Attribute.Type {
+func paramTypeBits(paramType uint8) int {
+	switch paramType {
+	case MNL_TYPE_U8:
+		return 8
+	case MNL_TYPE_U16:
+		return 16
+	case MNL_TYPE_U32:
+		return 32
+	case MNL_TYPE_U64:
+		return 64
+	}
+	return 0
+}
+
+func paramStringToValue(paramType uint8, value string) (any, error) {
+	switch paramType {
 	case MNL_TYPE_U8, MNL_TYPE_U16, MNL_TYPE_U32, MNL_TYPE_U64:
-		if val, err := strconv.Atoi(NewValue); err != nil {
-			return err
-		} else {
-			switch setParam.Attribute.Type {
-			case MNL_TYPE_U8:
-				req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_VALUE_DATA, nl.Uint8Attr(uint8(val))))
-			case MNL_TYPE_U16:
-				req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_VALUE_DATA, nl.Uint16Attr(uint16(val))))
-			case MNL_TYPE_U32:
-				req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_VALUE_DATA, nl.Uint32Attr(uint32(val))))
-			case MNL_TYPE_U64:
-				req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_VALUE_DATA, nl.Uint64Attr(uint64(val))))
-			}
-		}
+		return strconv.ParseUint(value, 10, paramTypeBits(paramType))
 	case MNL_TYPE_STRING:
-		b := make([]byte, len(NewValue)+1)
-		copy(b, NewValue)
-		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_VALUE_DATA, b))
+		return value, nil
 	case MNL_TYPE_FLAG:
-		if NewValue != "true" && NewValue != "false" {
-			return fmt.Errorf("invalid value for the flag parameter. Should be true/false")
-		}
-
-		if NewValue == "true" {
-			// To pass the true flag value, we need to add an empty VALUE_DATE field
-			// for the false value, the field should not be present
-			req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PARAM_VALUE_DATA, []byte{}))
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("invalid value for the flag parameter. Should be true/false")
 		}
+		return value == "true", nil
 	}
+	return nil, fmt.Errorf("unsupported parameter type %d", paramType)
+}
 
-	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
-	if err != nil {
-		return err
+func paramUintValue(value any) (uint64, error) {
+	switch v := value.(type) {
+	case uint:
+		return uint64(v), nil
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case int:
+		return paramIntValue(int64(v))
+	case int8:
+		return paramIntValue(int64(v))
+	case int16:
+		return paramIntValue(int64(v))
+	case int32:
+		return paramIntValue(int64(v))
+	case int64:
+		return paramIntValue(v)
 	}
+	return 0, fmt.Errorf("invalid value type %T for integer parameter", value)
+}
 
-	fmt.Println(err)
-	return err
+func paramIntValue(value int64) (uint64, error) {
+	if value < 0 {
+		return 0, fmt.Errorf("negative value %d for unsigned parameter", value)
+	}
+	return uint64(value), nil
 }
 
-// DevlinkDevParamSet sets one device parameter.
-// It returns 0 on success or error code.
-// Equivalent to: `mlxdevm dev param set $dev name disable_netdev value true cmode runtime`
-func DevlinkDevParamSet(Socket string, Bus string, Device string, ParamName string, NewValue string, NewCMode string) error {
-	return pkgHandle.DevlinkDevParamSet(Socket, Bus, Device, ParamName, NewValue, NewCMode)
+// paramValueData encodes a parameter value as DEVLINK_ATTR_PARAM_VALUE_DATA
+// payload. A false flag is encoded as nil since it has no data attribute.
+func paramValueData(paramType uint8, value any) ([]byte, error) {
+	switch paramType {
+	case MNL_TYPE_U8, MNL_TYPE_U16, MNL_TYPE_U32, MNL_TYPE_U64:
+		val, err := paramUintValue(value)
+		if err != nil {
+			return nil, err
+		}
+		bits := paramTypeBits(paramType)
+		if bits < 64 && val >= 1<<bits {
+			return nil, fmt.Errorf("value %d out of range for u%d parameter", val, bits)
+		}
+		switch paramType {
+		case MNL_TYPE_U8:
+			return nl.Uint8Attr(uint8(val)), nil
+		case MNL_TYPE_U16:
+			return nl.Uint16Attr(uint16(val)), nil
+		case MNL_TYPE_U32:
+			return nl.Uint32Attr(uint32(val)), nil
+		default:
+			return nl.Uint64Attr(val), nil
+		}
+	case MNL_TYPE_STRING:
+		val, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value type %T for string parameter", value)
+		}
+		return nl.ZeroTerminated(val), nil
+	case MNL_TYPE_FLAG:
+		val, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid value type %T for flag parameter", value)
+		}
+		if val {
+			return []byte{}, nil
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported parameter type %d", paramType)
 }
 
 // DevlinkGetDeviceResources returns devlink device resources from provided socket
@@ -984,3 +2083,3628 @@ func (h *Handle) DevlinkGetDeviceResources(socket string, bus string, device str
 
 	return &resources, nil
 }
+
+// ResourceByPath returns the resource at the slash separated path, e.g. "/kvd/linear"
+func (dlrs *DevlinkResources) ResourceByPath(path string) (*DevlinkResource, error) {
+	var found *DevlinkResource
+	resources := dlrs.Resources
+	for _, name := range strings.Split(path, "/") {
+		if name == "" {
+			continue
+		}
+		found = nil
+		for i := range resources {
+			if resources[i].Name == name {
+				found = &resources[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("resource %s not found", path)
+		}
+		resources = found.Children
+	}
+	if found == nil {
+		return nil, fmt.Errorf("invalid resource path %q", path)
+	}
+	return found, nil
+}
+
+// ResourceByID returns the resource with the given ID
+func (dlrs *DevlinkResources) ResourceByID(id uint64) (*DevlinkResource, error) {
+	var found *DevlinkResource
+	_ = dlrs.Walk(func(r *DevlinkResource) error {
+		if r.ID == id {
+			found = r
+			return errStopWalk
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, fmt.Errorf("resource id %d not found", id)
+	}
+	return found, nil
+}
+
+var errStopWalk = errors.New("stop walk")
+
+// Walk visits all resources depth-first, parents before their children.
+// The walk stops at the first error returned by the visitor, which is
+// returned by Walk.
+func (dlrs *DevlinkResources) Walk(visit func(r *DevlinkResource) error) error {
+	for i := range dlrs.Resources {
+		if err := dlrs.Resources[i].walk(visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dlr *DevlinkResource) walk(visit func(r *DevlinkResource) error) error {
+	if err := visit(dlr); err != nil {
+		return err
+	}
+	for i := range dlr.Children {
+		if err := dlr.Children[i].walk(visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Path returns the slash separated path of the resource
+func (dlr *DevlinkResource) Path() string {
+	path := "/" + dlr.Name
+	for p := dlr.Parent; p != nil; p = p.Parent {
+		path = "/" + p.Name + path
+	}
+	return path
+}
+
+// Free returns the unoccupied capacity of the resource. The second return
+// value is false when the device does not report the resource occupancy.
+func (dlr *DevlinkResource) Free() (uint64, bool) {
+	if !dlr.OCCValid {
+		return 0, false
+	}
+	if dlr.OCCSize >= dlr.Size {
+		return 0, true
+	}
+	return dlr.Size - dlr.OCCSize, true
+}
+
+// validateSize checks a new resource size against the resource limits
+func (dlr *DevlinkResource) validateSize(size uint64) error {
+	if size < dlr.SizeMin || size > dlr.SizeMax {
+		return fmt.Errorf("resource %s size %d out of range [%d, %d]", dlr.Name, size, dlr.SizeMin, dlr.SizeMax)
+	}
+	if dlr.SizeGranularity != 0 && size%dlr.SizeGranularity != 0 {
+		return fmt.Errorf("resource %s size %d is not a multiple of %d", dlr.Name, size, dlr.SizeGranularity)
+	}
+	return nil
+}
+
+// DevlinkResourceSet sets the size of the resource at the slash separated
+// Path, e.g. "/max_local_SFs". The size is validated against the resource
+// limits before it is sent, and a new size only takes effect after a devlink
+// reload. It returns whether a reload is needed, otherwise returns an error code.
+// Equivalent to: `devlink resource set $dev path /max_local_SFs size 64`
+func (h *Handle) DevlinkResourceSet(Socket string, Bus string, Device string, Path string, Size uint64) (bool, error) {
+	resources, err := h.DevlinkGetDeviceResources(Socket, Bus, Device)
+	if err != nil {
+		return false, err
+	}
+	resource, err := resources.ResourceByPath(Path)
+	if err != nil {
+		return false, err
+	}
+	if err = resource.validateSize(Size); err != nil {
+		return false, err
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_RESOURCE_SET, Bus, Device)
+	if err != nil {
+		return false, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_RESOURCE_ID, nl.Uint64Attr(resource.ID)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_RESOURCE_SIZE, nl.Uint64Attr(Size)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return false, err
+	}
+	return Size != resource.Size, nil
+}
+
+// DevlinkResourceSet sets the size of the resource at the slash separated
+// Path, e.g. "/max_local_SFs". The size is validated against the resource
+// limits before it is sent, and a new size only takes effect after a devlink
+// reload. It returns whether a reload is needed, otherwise returns an error code.
+// Equivalent to: `devlink resource set $dev path /max_local_SFs size 64`
+func DevlinkResourceSet(Socket string, Bus string, Device string, Path string, Size uint64) (bool, error) {
+	return pkgHandle.DevlinkResourceSet(Socket, Bus, Device, Path, Size)
+}
+
+// DevlinkResourceFree returns the unoccupied capacity of the resource at
+// the slash separated Path, e.g. how many more SFs fit on the device with
+// "/max_local_SFs", otherwise returns an error code.
+func (h *Handle) DevlinkResourceFree(Socket string, Bus string, Device string, Path string) (uint64, error) {
+	resources, err := h.DevlinkGetDeviceResources(Socket, Bus, Device)
+	if err != nil {
+		return 0, err
+	}
+	resource, err := resources.ResourceByPath(Path)
+	if err != nil {
+		return 0, err
+	}
+	free, ok := resource.Free()
+	if !ok {
+		return 0, fmt.Errorf("resource %s occupancy is not reported", Path)
+	}
+	return free, nil
+}
+
+// DevlinkResourceFree returns the unoccupied capacity of the resource at
+// the slash separated Path, e.g. how many more SFs fit on the device with
+// "/max_local_SFs", otherwise returns an error code.
+func DevlinkResourceFree(Socket string, Bus string, Device string, Path string) (uint64, error) {
+	return pkgHandle.DevlinkResourceFree(Socket, Bus, Device, Path)
+}
+
+func (h *Handle) createDumpReq(Socket string, cmd uint8) (*GenlFamily, *nl.NetlinkRequest, error) {
+	f, err := h.GenlFamilyGet(Socket)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msg := &nl.Genlmsg{
+		Command: cmd,
+		Version: nl.GENL_DEVLINK_VERSION,
+	}
+	req := h.newNetlinkRequest(int(f.ID),
+		unix.NLM_F_REQUEST|unix.NLM_F_ACK|unix.NLM_F_DUMP)
+	req.AddData(msg)
+
+	return f, req, nil
+}
+
+func parseDevlinkInfoVersion(data []byte) (DevlinkDeviceInfoVersion, error) {
+	var ver DevlinkDeviceInfoVersion
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return ver, err
+	}
+	for _, a := range attrs {
+		switch a.Attr.Type {
+		case DEVLINK_ATTR_INFO_VERSION_NAME:
+			ver.Name = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_INFO_VERSION_VALUE:
+			ver.Value = nl.BytesToString(a.Value)
+		}
+	}
+	return ver, nil
+}
+
+func (info *DevlinkDeviceInfo) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			info.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			info.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_INFO_DRIVER_NAME:
+			info.Driver = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_INFO_SERIAL_NUMBER:
+			info.SerialNumber = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_INFO_BOARD_SERIAL_NUMBER:
+			info.BoardSerialNumber = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_INFO_VERSION_FIXED:
+			ver, err := parseDevlinkInfoVersion(a.Value)
+			if err != nil {
+				return err
+			}
+			info.FixedVersions = append(info.FixedVersions, ver)
+		case DEVLINK_ATTR_INFO_VERSION_RUNNING:
+			ver, err := parseDevlinkInfoVersion(a.Value)
+			if err != nil {
+				return err
+			}
+			info.RunningVersions = append(info.RunningVersions, ver)
+		case DEVLINK_ATTR_INFO_VERSION_STORED:
+			ver, err := parseDevlinkInfoVersion(a.Value)
+			if err != nil {
+				return err
+			}
+			info.StoredVersions = append(info.StoredVersions, ver)
+		}
+	}
+	return nil
+}
+
+func parseDevlinkDeviceInfoList(msgs [][]byte) ([]*DevlinkDeviceInfo, error) {
+	infos := make([]*DevlinkDeviceInfo, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		info := &DevlinkDeviceInfo{}
+		if err = info.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// DevlinkGetDeviceInfo returns the driver name, serial numbers and
+// component versions of a devlink device, otherwise returns an error code.
+// Equivalent to: `devlink dev info $dev`
+func (h *Handle) DevlinkGetDeviceInfo(Socket string, Bus string, Device string) (*DevlinkDeviceInfo, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_INFO_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := parseDevlinkDeviceInfoList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return infos[0], nil
+}
+
+// DevlinkGetDeviceInfo returns the driver name, serial numbers and
+// component versions of a devlink device, otherwise returns an error code.
+// Equivalent to: `devlink dev info $dev`
+func DevlinkGetDeviceInfo(Socket string, Bus string, Device string) (*DevlinkDeviceInfo, error) {
+	return pkgHandle.DevlinkGetDeviceInfo(Socket, Bus, Device)
+}
+
+// DevlinkGetDeviceInfoList returns device information of all devlink devices,
+// otherwise returns an error code.
+// Equivalent to: `devlink dev info`
+func (h *Handle) DevlinkGetDeviceInfoList(Socket string) ([]*DevlinkDeviceInfo, error) {
+	_, req, err := h.createDumpReq(Socket, DEVLINK_CMD_INFO_GET)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkDeviceInfoList(msgs)
+}
+
+// DevlinkGetDeviceInfoList returns device information of all devlink devices,
+// otherwise returns an error code.
+// Equivalent to: `devlink dev info`
+func DevlinkGetDeviceInfoList(Socket string) ([]*DevlinkDeviceInfo, error) {
+	return pkgHandle.DevlinkGetDeviceInfoList(Socket)
+}
+
+func bitfield32Attr(value uint32, selector uint32) []byte {
+	b := make([]byte, 8)
+	native.PutUint32(b[0:4], value)
+	native.PutUint32(b[4:8], selector)
+	return b
+}
+
+// DevlinkDevReload reloads a devlink device with the requested action and
+// limit, optionally moving it to another network namespace. It returns the
+// bitmask of actions performed by the kernel on success or an error code.
+// The bitmask is reported only when an explicit action is requested.
+// Equivalent to: `devlink dev reload $dev action fw_activate limit no_reset`
+// Equivalent to: `devlink dev reload $dev netns $ns`
+func (h *Handle) DevlinkDevReload(Socket string, Bus string, Device string, Attrs DevlinkDevReloadAttrs) (uint32, error) {
+	if Attrs.LimitValid && (Attrs.Limit == DEVLINK_RELOAD_LIMIT_UNSPEC || Attrs.Limit > DEVLINK_RELOAD_LIMIT_MAX) {
+		return 0, fmt.Errorf("invalid reload limit %d", Attrs.Limit)
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_RELOAD, Bus, Device)
+	if err != nil {
+		return 0, err
+	}
+
+	if Attrs.ActionValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_RELOAD_ACTION, nl.Uint8Attr(Attrs.Action)))
+	}
+	if Attrs.LimitValid {
+		limit := uint32(1) << Attrs.Limit
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_RELOAD_LIMITS, bitfield32Attr(limit, limit)))
+	}
+	if Attrs.NetnsFdValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_NETNS_FD, nl.Uint32Attr(Attrs.NetnsFd)))
+	}
+	if Attrs.NetnsPidValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_NETNS_PID, nl.Uint32Attr(Attrs.NetnsPid)))
+	}
+	if Attrs.NetnsIdValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_NETNS_ID, nl.Uint32Attr(uint32(Attrs.NetnsId))))
+	}
+
+	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return 0, err
+	}
+	if len(respmsg) == 0 {
+		return 0, nil
+	}
+
+	attrs, err := nl.ParseRouteAttrAsMap(respmsg[0][nl.SizeofGenlmsg:])
+	if err != nil {
+		return 0, err
+	}
+	attr, ok := attrs[DEVLINK_ATTR_RELOAD_ACTIONS_PERFORMED]
+	if !ok {
+		return 0, nil
+	}
+	return native.Uint32(attr.Value[0:4]), nil
+}
+
+// DevlinkDevReload reloads a devlink device with the requested action and
+// limit, optionally moving it to another network namespace. It returns the
+// bitmask of actions performed by the kernel on success or an error code.
+// The bitmask is reported only when an explicit action is requested.
+// Equivalent to: `devlink dev reload $dev action fw_activate limit no_reset`
+// Equivalent to: `devlink dev reload $dev netns $ns`
+func DevlinkDevReload(Socket string, Bus string, Device string, Attrs DevlinkDevReloadAttrs) (uint32, error) {
+	return pkgHandle.DevlinkDevReload(Socket, Bus, Device, Attrs)
+}
+
+// subscribeConfigGroup returns a netlink socket in the network namespace of
+// the handle joined to the config multicast group of the given family
+func (h *Handle) subscribeConfigGroup(family *GenlFamily) (*nl.NetlinkSocket, error) {
+	var groupID uint32
+	found := false
+	for _, g := range family.Groups {
+		if g.Name == DEVLINK_GENL_MCGRP_CONFIG_NAME {
+			groupID = g.ID
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("multicast group '%s' not found for family '%s'", DEVLINK_GENL_MCGRP_CONFIG_NAME, family.Name)
+	}
+
+	s, err := nl.SubscribeAt(h.nsHandle(), netns.None(), unix.NETLINK_GENERIC)
+	if err != nil {
+		return nil, err
+	}
+	err = unix.SetsockoptInt(s.GetFd(), unix.SOL_NETLINK, unix.NETLINK_ADD_MEMBERSHIP, int(groupID))
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (st *DevlinkFlashStatus) parseAttributes(attrs []syscall.NetlinkRouteAttr) {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_FLASH_UPDATE_STATUS_MSG:
+			st.Message = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_FLASH_UPDATE_COMPONENT:
+			st.Component = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_FLASH_UPDATE_STATUS_DONE:
+			st.Done = native.Uint64(a.Value)
+		case DEVLINK_ATTR_FLASH_UPDATE_STATUS_TOTAL:
+			st.Total = native.Uint64(a.Value)
+		case DEVLINK_ATTR_FLASH_UPDATE_STATUS_TIMEOUT:
+			st.Timeout = native.Uint64(a.Value)
+		}
+	}
+}
+
+// isDevlinkDeviceMsg reports whether the netlink attributes belong to the given device
+func isDevlinkDeviceMsg(attrs []syscall.NetlinkRouteAttr, Bus string, Device string) bool {
+	var msgBus, msgDevice string
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			msgBus = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			msgDevice = nl.BytesToString(a.Value)
+		}
+	}
+	return msgBus == Bus && msgDevice == Device
+}
+
+// receiveFlashStatus delivers flash status notifications of the given device
+// until the flash end notification arrives, or until done is closed and no
+// more notifications are queued on the socket.
+func receiveFlashStatus(s *nl.NetlinkSocket, family *GenlFamily, Bus string, Device string,
+	done <-chan struct{}, status func(DevlinkFlashStatus)) {
+	fds := []unix.PollFd{{Fd: int32(s.GetFd()), Events: unix.POLLIN}}
+	for {
+		n, err := unix.Poll(fds, 100)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		if n == 0 {
+			select {
+			case <-done:
+				return
+			default:
+				continue
+			}
+		}
+
+		msgs, _, err := s.Receive()
+		if err != nil {
+			return
+		}
+		for _, m := range msgs {
+			if m.Header.Type != family.ID || len(m.Data) < nl.SizeofGenlmsg {
+				continue
+			}
+			cmd := m.Data[0]
+			if cmd != DEVLINK_CMD_FLASH_UPDATE_STATUS && cmd != DEVLINK_CMD_FLASH_UPDATE_END {
+				continue
+			}
+			attrs, err := nl.ParseRouteAttr(m.Data[nl.SizeofGenlmsg:])
+			if err != nil || !isDevlinkDeviceMsg(attrs, Bus, Device) {
+				continue
+			}
+			if cmd == DEVLINK_CMD_FLASH_UPDATE_END {
+				return
+			}
+			var st DevlinkFlashStatus
+			st.parseAttributes(attrs)
+			status(st)
+		}
+	}
+}
+
+// DevlinkDevFlash updates device firmware with the given file, optionally
+// limited to a single component. When status is not nil, it is called from a
+// separate goroutine for every progress notification of the device while the
+// request is in flight; it is never called after DevlinkDevFlash returns.
+// Flashing may take longer than the default socket timeout, which can be
+// raised with SetSocketTimeout.
+// It returns nil on success or error code.
+// Equivalent to: `devlink dev flash $dev file $file component $component overwrite settings`
+func (h *Handle) DevlinkDevFlash(Socket string, Bus string, Device string, Attrs DevlinkFlashUpdateAttrs, status func(DevlinkFlashStatus)) error {
+	if Attrs.FileName == "" {
+		return fmt.Errorf("flash file name is required")
+	}
+
+	f, req, err := h.createCmdReq(Socket, DEVLINK_CMD_FLASH_UPDATE, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_FLASH_UPDATE_FILE_NAME, nl.ZeroTerminated(Attrs.FileName)))
+	if Attrs.Component != "" {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_FLASH_UPDATE_COMPONENT, nl.ZeroTerminated(Attrs.Component)))
+	}
+	if Attrs.OverwriteMask != 0 {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_FLASH_UPDATE_OVERWRITE_MASK,
+			bitfield32Attr(Attrs.OverwriteMask, Attrs.OverwriteMask)))
+	}
+
+	if status == nil {
+		_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+		return err
+	}
+
+	// subscribe before sending the request so no notification is missed
+	s, err := h.subscribeConfigGroup(f)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		receiveFlashStatus(s, f, Bus, Device, done, status)
+	}()
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	close(done)
+	wg.Wait()
+	return err
+}
+
+// DevlinkDevFlash updates device firmware with the given file, optionally
+// limited to a single component. When status is not nil, it is called from a
+// separate goroutine for every progress notification of the device while the
+// request is in flight; it is never called after DevlinkDevFlash returns.
+// Flashing may take longer than the default socket timeout, which can be
+// raised with SetSocketTimeout.
+// It returns nil on success or error code.
+// Equivalent to: `devlink dev flash $dev file $file component $component overwrite settings`
+func DevlinkDevFlash(Socket string, Bus string, Device string, Attrs DevlinkFlashUpdateAttrs, status func(DevlinkFlashStatus)) error {
+	return pkgHandle.DevlinkDevFlash(Socket, Bus, Device, Attrs, status)
+}
+
+func (r *DevlinkHealthReporter) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			r.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			r.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_PORT_INDEX:
+			r.PortIndex = native.Uint32(a.Value)
+			r.PortIndexValid = true
+		case DEVLINK_ATTR_HEALTH_REPORTER:
+			nested, err := nl.ParseRouteAttr(a.Value)
+			if err != nil {
+				return err
+			}
+			for _, na := range nested {
+				switch na.Attr.Type & nl.NLA_TYPE_MASK {
+				case DEVLINK_ATTR_HEALTH_REPORTER_NAME:
+					r.Name = nl.BytesToString(na.Value)
+				case DEVLINK_ATTR_HEALTH_REPORTER_STATE:
+					r.State = uint8(na.Value[0])
+				case DEVLINK_ATTR_HEALTH_REPORTER_ERR_COUNT:
+					r.ErrorCount = native.Uint64(na.Value)
+				case DEVLINK_ATTR_HEALTH_REPORTER_RECOVER_COUNT:
+					r.RecoverCount = native.Uint64(na.Value)
+				case DEVLINK_ATTR_HEALTH_REPORTER_GRACEFUL_PERIOD:
+					r.GracePeriod = native.Uint64(na.Value)
+				case DEVLINK_ATTR_HEALTH_REPORTER_AUTO_RECOVER:
+					r.AutoRecover = uint8(na.Value[0]) != 0
+				case DEVLINK_ATTR_HEALTH_REPORTER_AUTO_DUMP:
+					r.AutoDump = uint8(na.Value[0]) != 0
+				case DEVLINK_ATTR_HEALTH_REPORTER_DUMP_TS:
+					r.DumpTs = native.Uint64(na.Value)
+				case DEVLINK_ATTR_HEALTH_REPORTER_DUMP_TS_NS:
+					r.DumpTsNs = native.Uint64(na.Value)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func parseDevlinkHealthReporterList(msgs [][]byte) ([]*DevlinkHealthReporter, error) {
+	reporters := make([]*DevlinkHealthReporter, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		reporter := &DevlinkHealthReporter{}
+		if err = reporter.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		reporters = append(reporters, reporter)
+	}
+	return reporters, nil
+}
+
+// createHealthReporterReq creates a health reporter request for a device
+// reporter, or for a port reporter when Reporter.PortIndexValid is set
+func (h *Handle) createHealthReporterReq(Socket string, cmd uint8, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) (*GenlFamily, *nl.NetlinkRequest, error) {
+	f, req, err := h.createCmdReq(Socket, cmd, Bus, Device)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if Reporter.PortIndexValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(Reporter.PortIndex)))
+	}
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_HEALTH_REPORTER_NAME, nl.ZeroTerminated(Reporter.Name)))
+
+	return f, req, nil
+}
+
+// DevlinkHealthReporterList returns all device and port health reporters of a
+// devlink device, otherwise returns an error code.
+// Equivalent to: `devlink health show $dev`
+func (h *Handle) DevlinkHealthReporterList(Socket string, Bus string, Device string) ([]*DevlinkHealthReporter, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_HEALTH_REPORTER_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkHealthReporterList(msgs)
+}
+
+// DevlinkHealthReporterList returns all device and port health reporters of a
+// devlink device, otherwise returns an error code.
+// Equivalent to: `devlink health show $dev`
+func DevlinkHealthReporterList(Socket string, Bus string, Device string) ([]*DevlinkHealthReporter, error) {
+	return pkgHandle.DevlinkHealthReporterList(Socket, Bus, Device)
+}
+
+// DevlinkHealthReporterGet returns a device health reporter, or a port health
+// reporter when Reporter.PortIndexValid is set, otherwise returns an error code.
+// Equivalent to: `devlink health show $dev reporter tx`
+// Equivalent to: `devlink health show $port reporter tx`
+func (h *Handle) DevlinkHealthReporterGet(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) (*DevlinkHealthReporter, error) {
+	_, req, err := h.createHealthReporterReq(Socket, DEVLINK_CMD_HEALTH_REPORTER_GET, Bus, Device, Reporter)
+	if err != nil {
+		return nil, err
+	}
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	reporters, err := parseDevlinkHealthReporterList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return reporters[0], nil
+}
+
+// DevlinkHealthReporterGet returns a device health reporter, or a port health
+// reporter when Reporter.PortIndexValid is set, otherwise returns an error code.
+// Equivalent to: `devlink health show $dev reporter tx`
+// Equivalent to: `devlink health show $port reporter tx`
+func DevlinkHealthReporterGet(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) (*DevlinkHealthReporter, error) {
+	return pkgHandle.DevlinkHealthReporterGet(Socket, Bus, Device, Reporter)
+}
+
+// DevlinkHealthReporterSet sets the grace period and the auto recover and
+// auto dump flags of a device or port health reporter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink health set $dev reporter tx grace_period 500 auto_recover true auto_dump false`
+func (h *Handle) DevlinkHealthReporterSet(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs, Attrs DevlinkHealthReporterSetAttrs) error {
+	_, req, err := h.createHealthReporterReq(Socket, DEVLINK_CMD_HEALTH_REPORTER_SET, Bus, Device, Reporter)
+	if err != nil {
+		return err
+	}
+
+	if Attrs.GracePeriodValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_HEALTH_REPORTER_GRACEFUL_PERIOD, nl.Uint64Attr(Attrs.GracePeriod)))
+	}
+	if Attrs.AutoRecoverValid {
+		autoRecover := uint8(0)
+		if Attrs.AutoRecover {
+			autoRecover = 1
+		}
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_HEALTH_REPORTER_AUTO_RECOVER, nl.Uint8Attr(autoRecover)))
+	}
+	if Attrs.AutoDumpValid {
+		autoDump := uint8(0)
+		if Attrs.AutoDump {
+			autoDump = 1
+		}
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_HEALTH_REPORTER_AUTO_DUMP, nl.Uint8Attr(autoDump)))
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkHealthReporterSet sets the grace period and the auto recover and
+// auto dump flags of a device or port health reporter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink health set $dev reporter tx grace_period 500 auto_recover true auto_dump false`
+func DevlinkHealthReporterSet(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs, Attrs DevlinkHealthReporterSetAttrs) error {
+	return pkgHandle.DevlinkHealthReporterSet(Socket, Bus, Device, Reporter, Attrs)
+}
+
+// DevlinkHealthReporterRecover triggers the recovery of a device or port health reporter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink health recover $dev reporter tx`
+func (h *Handle) DevlinkHealthReporterRecover(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) error {
+	_, req, err := h.createHealthReporterReq(Socket, DEVLINK_CMD_HEALTH_REPORTER_RECOVER, Bus, Device, Reporter)
+	if err != nil {
+		return err
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkHealthReporterRecover triggers the recovery of a device or port health reporter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink health recover $dev reporter tx`
+func DevlinkHealthReporterRecover(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) error {
+	return pkgHandle.DevlinkHealthReporterRecover(Socket, Bus, Device, Reporter)
+}
+
+// DevlinkHealthReporterTest triggers a fake error on a device or port health reporter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink health test $dev reporter tx`
+func (h *Handle) DevlinkHealthReporterTest(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) error {
+	_, req, err := h.createHealthReporterReq(Socket, DEVLINK_CMD_HEALTH_REPORTER_TEST, Bus, Device, Reporter)
+	if err != nil {
+		return err
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkHealthReporterTest triggers a fake error on a device or port health reporter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink health test $dev reporter tx`
+func DevlinkHealthReporterTest(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) error {
+	return pkgHandle.DevlinkHealthReporterTest(Socket, Bus, Device, Reporter)
+}
+
+// Get returns the value of the first pair with the given name
+func (o DevlinkFmsgObject) Get(name string) (any, bool) {
+	for _, pair := range o {
+		if pair.Name == name {
+			return pair.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Map converts the object and all nested objects into maps. Pairs with a
+// repeated name keep the last value.
+func (o DevlinkFmsgObject) Map() map[string]any {
+	m := make(map[string]any, len(o))
+	for _, pair := range o {
+		m[pair.Name] = fmsgValueToMap(pair.Value)
+	}
+	return m
+}
+
+func fmsgValueToMap(value any) any {
+	switch v := value.(type) {
+	case DevlinkFmsgObject:
+		return v.Map()
+	case []any:
+		values := make([]any, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmsgValueToMap(item))
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+// MarshalJSON encodes the object as a JSON object keeping the pair order
+func (o DevlinkFmsgObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, pair := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(pair.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(pair.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// fmsgParser decodes the flat stream of formatted message attributes into a tree
+type fmsgParser struct {
+	attrs []syscall.NetlinkRouteAttr
+	pos   int
+}
+
+func (p *fmsgParser) next() (syscall.NetlinkRouteAttr, error) {
+	if p.pos >= len(p.attrs) {
+		return syscall.NetlinkRouteAttr{}, fmt.Errorf("unexpected end of formatted message")
+	}
+	a := p.attrs[p.pos]
+	p.pos++
+	return a, nil
+}
+
+func (p *fmsgParser) peek() uint16 {
+	if p.pos >= len(p.attrs) {
+		return 0
+	}
+	return p.attrs[p.pos].Attr.Type & nl.NLA_TYPE_MASK
+}
+
+func (p *fmsgParser) parseObject() (DevlinkFmsgObject, error) {
+	obj := DevlinkFmsgObject{}
+	for {
+		a, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_FMSG_NEST_END:
+			return obj, nil
+		case DEVLINK_ATTR_FMSG_PAIR_NEST_START:
+			pair, err := p.parsePair()
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, pair)
+		default:
+			return nil, fmt.Errorf("unexpected formatted message attribute %d in object", a.Attr.Type)
+		}
+	}
+}
+
+func (p *fmsgParser) parsePair() (DevlinkFmsgPair, error) {
+	var pair DevlinkFmsgPair
+	a, err := p.next()
+	if err != nil {
+		return pair, err
+	}
+	if a.Attr.Type&nl.NLA_TYPE_MASK != DEVLINK_ATTR_FMSG_OBJ_NAME {
+		return pair, fmt.Errorf("missing formatted message pair name")
+	}
+	pair.Name = nl.BytesToString(a.Value)
+
+	if p.peek() != DEVLINK_ATTR_FMSG_NEST_END {
+		pair.Value, err = p.parseValue()
+		if err != nil {
+			return pair, err
+		}
+	}
+
+	a, err = p.next()
+	if err != nil {
+		return pair, err
+	}
+	if a.Attr.Type&nl.NLA_TYPE_MASK != DEVLINK_ATTR_FMSG_NEST_END {
+		return pair, fmt.Errorf("unterminated formatted message pair %s", pair.Name)
+	}
+	return pair, nil
+}
+
+func (p *fmsgParser) parseArray() ([]any, error) {
+	values := []any{}
+	for {
+		if p.peek() == DEVLINK_ATTR_FMSG_NEST_END {
+			p.pos++
+			return values, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+}
+
+func (p *fmsgParser) parseValue() (any, error) {
+	a, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch a.Attr.Type & nl.NLA_TYPE_MASK {
+	case DEVLINK_ATTR_FMSG_OBJ_NEST_START:
+		return p.parseObject()
+	case DEVLINK_ATTR_FMSG_ARR_NEST_START:
+		return p.parseArray()
+	case DEVLINK_ATTR_FMSG_OBJ_VALUE_TYPE:
+		if len(a.Value) < 1 {
+			return nil, fmt.Errorf("missing formatted message value type")
+		}
+		valueType := uint8(a.Value[0])
+		if p.peek() != DEVLINK_ATTR_FMSG_OBJ_VALUE_DATA {
+			return nil, fmt.Errorf("missing formatted message value data")
+		}
+		data, _ := p.next()
+		return parseFmsgValueData(valueType, data.Value)
+	default:
+		return nil, fmt.Errorf("unexpected formatted message attribute %d in value", a.Attr.Type)
+	}
+}
+
+func parseFmsgValueData(valueType uint8, data []byte) (any, error) {
+	var fmsgValueSizes = map[uint8]int{
+		MNL_TYPE_U8:   1,
+		MNL_TYPE_U16:  2,
+		MNL_TYPE_U32:  4,
+		MNL_TYPE_U64:  8,
+		MNL_TYPE_FLAG: 1,
+	}
+	if size, ok := fmsgValueSizes[valueType]; ok && len(data) < size {
+		return nil, fmt.Errorf("formatted message value of type %d too short: %d bytes", valueType, len(data))
+	}
+
+	switch valueType {
+	case MNL_TYPE_U8:
+		return uint8(data[0]), nil
+	case MNL_TYPE_U16:
+		return native.Uint16(data), nil
+	case MNL_TYPE_U32:
+		return native.Uint32(data), nil
+	case MNL_TYPE_U64:
+		return native.Uint64(data), nil
+	case MNL_TYPE_FLAG:
+		return data[0] != 0, nil
+	case MNL_TYPE_STRING, MNL_TYPE_NUL_STRING:
+		if i := bytes.IndexByte(data, 0); i >= 0 {
+			data = data[:i]
+		}
+		return string(data), nil
+	case MNL_TYPE_BINARY:
+		b := make([]byte, len(data))
+		copy(b, data)
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unsupported formatted message value type %d", valueType)
+	}
+}
+
+// parseDevlinkFmsg decodes the formatted message attribute stream of one or
+// more messages into an object. Top level objects are merged into one.
+func parseDevlinkFmsg(data []byte) (DevlinkFmsgObject, error) {
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return nil, err
+	}
+	p := &fmsgParser{attrs: attrs}
+	result := DevlinkFmsgObject{}
+	for p.pos < len(p.attrs) {
+		a, _ := p.next()
+		if a.Attr.Type&nl.NLA_TYPE_MASK != DEVLINK_ATTR_FMSG_OBJ_NEST_START {
+			return nil, fmt.Errorf("unexpected formatted message attribute %d at top level", a.Attr.Type)
+		}
+		obj, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, obj...)
+	}
+	return result, nil
+}
+
+// parseDevlinkFmsgMsgs concatenates the formatted message attributes of all
+// messages, as the kernel may split a single formatted message across them
+func parseDevlinkFmsgMsgs(msgs [][]byte) (DevlinkFmsgObject, error) {
+	var data []byte
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range attrs {
+			if a.Attr.Type&nl.NLA_TYPE_MASK == DEVLINK_ATTR_FMSG {
+				data = append(data, a.Value...)
+			}
+		}
+	}
+	return parseDevlinkFmsg(data)
+}
+
+// DevlinkHealthReporterDiagnose returns the diagnostic output of a device or
+// port health reporter, otherwise returns an error code.
+// Equivalent to: `devlink health diagnose $dev reporter tx`
+func (h *Handle) DevlinkHealthReporterDiagnose(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) (DevlinkFmsgObject, error) {
+	_, req, err := h.createHealthReporterReq(Socket, DEVLINK_CMD_HEALTH_REPORTER_DIAGNOSE, Bus, Device, Reporter)
+	if err != nil {
+		return nil, err
+	}
+
+	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkFmsgMsgs(respmsg)
+}
+
+// DevlinkHealthReporterDiagnose returns the diagnostic output of a device or
+// port health reporter, otherwise returns an error code.
+// Equivalent to: `devlink health diagnose $dev reporter tx`
+func DevlinkHealthReporterDiagnose(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) (DevlinkFmsgObject, error) {
+	return pkgHandle.DevlinkHealthReporterDiagnose(Socket, Bus, Device, Reporter)
+}
+
+// DevlinkHealthReporterDumpGet returns the last dump of a device or port
+// health reporter, creating one if none is stored, otherwise returns an error code.
+// Equivalent to: `devlink health dump show $dev reporter fw`
+func (h *Handle) DevlinkHealthReporterDumpGet(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) (DevlinkFmsgObject, error) {
+	_, req, err := h.createHealthReporterReq(Socket, DEVLINK_CMD_HEALTH_REPORTER_DUMP_GET, Bus, Device, Reporter)
+	if err != nil {
+		return nil, err
+	}
+	req.Flags |= unix.NLM_F_DUMP
+
+	msgs, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkFmsgMsgs(msgs)
+}
+
+// DevlinkHealthReporterDumpGet returns the last dump of a device or port
+// health reporter, creating one if none is stored, otherwise returns an error code.
+// Equivalent to: `devlink health dump show $dev reporter fw`
+func DevlinkHealthReporterDumpGet(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) (DevlinkFmsgObject, error) {
+	return pkgHandle.DevlinkHealthReporterDumpGet(Socket, Bus, Device, Reporter)
+}
+
+// DevlinkHealthReporterDumpClear deletes the stored dump of a device or port health reporter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink health dump clear $dev reporter fw`
+func (h *Handle) DevlinkHealthReporterDumpClear(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) error {
+	_, req, err := h.createHealthReporterReq(Socket, DEVLINK_CMD_HEALTH_REPORTER_DUMP_CLEAR, Bus, Device, Reporter)
+	if err != nil {
+		return err
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkHealthReporterDumpClear deletes the stored dump of a device or port health reporter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink health dump clear $dev reporter fw`
+func DevlinkHealthReporterDumpClear(Socket string, Bus string, Device string, Reporter DevlinkHealthReporterAttrs) error {
+	return pkgHandle.DevlinkHealthReporterDumpClear(Socket, Bus, Device, Reporter)
+}
+
+func (r *DevlinkRegion) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			r.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			r.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_PORT_INDEX:
+			r.PortIndex = native.Uint32(a.Value)
+			r.PortIndexValid = true
+		case DEVLINK_ATTR_REGION_NAME:
+			r.Name = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_REGION_SIZE:
+			r.Size = native.Uint64(a.Value)
+		case DEVLINK_ATTR_REGION_MAX_SNAPSHOTS:
+			r.MaxSnapshots = native.Uint32(a.Value)
+		case DEVLINK_ATTR_REGION_SNAPSHOTS:
+			snapshots, err := nl.ParseRouteAttr(a.Value)
+			if err != nil {
+				return err
+			}
+			for _, snapshot := range snapshots {
+				nested, err := nl.ParseRouteAttr(snapshot.Value)
+				if err != nil {
+					return err
+				}
+				for _, na := range nested {
+					if na.Attr.Type&nl.NLA_TYPE_MASK == DEVLINK_ATTR_REGION_SNAPSHOT_ID {
+						r.Snapshots = append(r.Snapshots, native.Uint32(na.Value))
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func parseDevlinkRegionList(msgs [][]byte) ([]*DevlinkRegion, error) {
+	regions := make([]*DevlinkRegion, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		region := &DevlinkRegion{}
+		if err = region.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
+
+// createRegionReq creates a region request for a device region, or for a
+// port region when Region.PortIndexValid is set
+func (h *Handle) createRegionReq(Socket string, cmd uint8, Bus string, Device string, Region DevlinkRegionAttrs) (*GenlFamily, *nl.NetlinkRequest, error) {
+	f, req, err := h.createCmdReq(Socket, cmd, Bus, Device)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if Region.PortIndexValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(Region.PortIndex)))
+	}
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_REGION_NAME, nl.ZeroTerminated(Region.Name)))
+
+	return f, req, nil
+}
+
+// DevlinkRegionList returns all device and port regions of a devlink device
+// with their size and snapshot IDs, otherwise returns an error code.
+// Equivalent to: `devlink region show`
+func (h *Handle) DevlinkRegionList(Socket string, Bus string, Device string) ([]*DevlinkRegion, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_REGION_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkRegionList(msgs)
+}
+
+// DevlinkRegionList returns all device and port regions of a devlink device
+// with their size and snapshot IDs, otherwise returns an error code.
+// Equivalent to: `devlink region show`
+func DevlinkRegionList(Socket string, Bus string, Device string) ([]*DevlinkRegion, error) {
+	return pkgHandle.DevlinkRegionList(Socket, Bus, Device)
+}
+
+// DevlinkRegionGet returns a device region, or a port region when
+// Region.PortIndexValid is set, otherwise returns an error code.
+// Equivalent to: `devlink region show $dev/cr-space`
+func (h *Handle) DevlinkRegionGet(Socket string, Bus string, Device string, Region DevlinkRegionAttrs) (*DevlinkRegion, error) {
+	_, req, err := h.createRegionReq(Socket, DEVLINK_CMD_REGION_GET, Bus, Device, Region)
+	if err != nil {
+		return nil, err
+	}
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := parseDevlinkRegionList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return regions[0], nil
+}
+
+// DevlinkRegionGet returns a device region, or a port region when
+// Region.PortIndexValid is set, otherwise returns an error code.
+// Equivalent to: `devlink region show $dev/cr-space`
+func DevlinkRegionGet(Socket string, Bus string, Device string, Region DevlinkRegionAttrs) (*DevlinkRegion, error) {
+	return pkgHandle.DevlinkRegionGet(Socket, Bus, Device, Region)
+}
+
+// DevlinkRegionSnapshotNew takes a new snapshot of a device or port region.
+// The snapshot ID is chosen by the kernel when SnapshotID is nil.
+// It returns the snapshot ID on success or an error code.
+// Equivalent to: `devlink region new $dev/cr-space snapshot 1`
+func (h *Handle) DevlinkRegionSnapshotNew(Socket string, Bus string, Device string, Region DevlinkRegionAttrs, SnapshotID *uint32) (uint32, error) {
+	_, req, err := h.createRegionReq(Socket, DEVLINK_CMD_REGION_NEW, Bus, Device, Region)
+	if err != nil {
+		return 0, err
+	}
+
+	if SnapshotID != nil {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_REGION_SNAPSHOT_ID, nl.Uint32Attr(*SnapshotID)))
+	}
+
+	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return 0, err
+	}
+	if SnapshotID != nil {
+		return *SnapshotID, nil
+	}
+
+	// the kernel replies with the allocated ID only when none was requested
+	if len(respmsg) != 1 {
+		return 0, fmt.Errorf("expected only one nl response msg")
+	}
+	attrs, err := nl.ParseRouteAttrAsMap(respmsg[0][nl.SizeofGenlmsg:])
+	if err != nil {
+		return 0, err
+	}
+	attr, ok := attrs[DEVLINK_ATTR_REGION_SNAPSHOT_ID]
+	if !ok {
+		return 0, fmt.Errorf("missing region snapshot id")
+	}
+	return native.Uint32(attr.Value), nil
+}
+
+// DevlinkRegionSnapshotNew takes a new snapshot of a device or port region.
+// The snapshot ID is chosen by the kernel when SnapshotID is nil.
+// It returns the snapshot ID on success or an error code.
+// Equivalent to: `devlink region new $dev/cr-space snapshot 1`
+func DevlinkRegionSnapshotNew(Socket string, Bus string, Device string, Region DevlinkRegionAttrs, SnapshotID *uint32) (uint32, error) {
+	return pkgHandle.DevlinkRegionSnapshotNew(Socket, Bus, Device, Region, SnapshotID)
+}
+
+// DevlinkRegionSnapshotDel deletes a snapshot of a device or port region.
+// It returns nil on success or error code.
+// Equivalent to: `devlink region del $dev/cr-space snapshot 1`
+func (h *Handle) DevlinkRegionSnapshotDel(Socket string, Bus string, Device string, Region DevlinkRegionAttrs, SnapshotID uint32) error {
+	_, req, err := h.createRegionReq(Socket, DEVLINK_CMD_REGION_DEL, Bus, Device, Region)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_REGION_SNAPSHOT_ID, nl.Uint32Attr(SnapshotID)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkRegionSnapshotDel deletes a snapshot of a device or port region.
+// It returns nil on success or error code.
+// Equivalent to: `devlink region del $dev/cr-space snapshot 1`
+func DevlinkRegionSnapshotDel(Socket string, Bus string, Device string, Region DevlinkRegionAttrs, SnapshotID uint32) error {
+	return pkgHandle.DevlinkRegionSnapshotDel(Socket, Bus, Device, Region, SnapshotID)
+}
+
+// writeDevlinkRegionChunks writes the chunk data of a region read message to w
+func writeDevlinkRegionChunks(m []byte, w io.Writer) error {
+	attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+	if err != nil {
+		return err
+	}
+	for _, a := range attrs {
+		if a.Attr.Type&nl.NLA_TYPE_MASK != DEVLINK_ATTR_REGION_CHUNKS {
+			continue
+		}
+		chunks, err := nl.ParseRouteAttr(a.Value)
+		if err != nil {
+			return err
+		}
+		for _, chunk := range chunks {
+			nested, err := nl.ParseRouteAttr(chunk.Value)
+			if err != nil {
+				return err
+			}
+			for _, na := range nested {
+				if na.Attr.Type&nl.NLA_TYPE_MASK != DEVLINK_ATTR_REGION_CHUNK_DATA {
+					continue
+				}
+				if _, err := w.Write(na.Value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// DevlinkRegionRead reads a snapshot of a device or port region, or the live
+// region when Attrs.Direct is set, and writes its content to w.
+// It returns nil on success or error code.
+// Equivalent to: `devlink region read $dev/cr-space snapshot 1 address 0 length 16`
+func (h *Handle) DevlinkRegionRead(Socket string, Bus string, Device string, Region DevlinkRegionAttrs, Attrs DevlinkRegionReadAttrs, w io.Writer) error {
+	_, req, err := h.createRegionReq(Socket, DEVLINK_CMD_REGION_READ, Bus, Device, Region)
+	if err != nil {
+		return err
+	}
+	req.Flags |= unix.NLM_F_DUMP
+
+	if Attrs.Direct {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_REGION_DIRECT, []byte{}))
+	} else {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_REGION_SNAPSHOT_ID, nl.Uint32Attr(Attrs.SnapshotID)))
+	}
+	if Attrs.Length != 0 {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_REGION_CHUNK_ADDR, nl.Uint64Attr(Attrs.Address)))
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_REGION_CHUNK_LEN, nl.Uint64Attr(Attrs.Length)))
+	}
+
+	// write each message as it arrives instead of buffering the whole region
+	return h.executeDumpIter(req, func(m []byte) error {
+		return writeDevlinkRegionChunks(m, w)
+	})
+}
+
+// DevlinkRegionRead reads a snapshot of a device or port region, or the live
+// region when Attrs.Direct is set, and writes its content to w.
+// It returns nil on success or error code.
+// Equivalent to: `devlink region read $dev/cr-space snapshot 1 address 0 length 16`
+func DevlinkRegionRead(Socket string, Bus string, Device string, Region DevlinkRegionAttrs, Attrs DevlinkRegionReadAttrs, w io.Writer) error {
+	return pkgHandle.DevlinkRegionRead(Socket, Bus, Device, Region, Attrs, w)
+}
+
+// devlinkRateIDs holds the rate command and attribute IDs of a netlink family
+type devlinkRateIDs struct {
+	getCmd     uint8
+	setCmd     uint8
+	newCmd     uint8
+	delCmd     uint8
+	typeAttr   uint16
+	shareAttr  uint16
+	maxAttr    uint16
+	nodeAttr   uint16
+	parentAttr uint16
+}
+
+// rateIDs returns the rate command and attribute IDs used by the given
+// family, as 'mlxdevm' exposes rates through its extension range
+func rateIDs(Socket string) devlinkRateIDs {
+	if Socket == GENL_MLXDEVM_NAME {
+		return devlinkRateIDs{
+			getCmd:     DEVLINK_CMD_EXT_RATE_GET,
+			setCmd:     DEVLINK_CMD_EXT_RATE_SET,
+			newCmd:     DEVLINK_CMD_EXT_RATE_NEW,
+			delCmd:     DEVLINK_CMD_EXT_RATE_DEL,
+			typeAttr:   DEVLINK_ATTR_EXT_RATE_TYPE,
+			shareAttr:  DEVLINK_ATTR_EXT_RATE_TX_SHARE,
+			maxAttr:    DEVLINK_ATTR_EXT_RATE_TX_MAX,
+			nodeAttr:   DEVLINK_ATTR_EXT_RATE_NODE_NAME,
+			parentAttr: DEVLINK_ATTR_EXT_RATE_PARENT_NODE_NAME,
+		}
+	}
+	return devlinkRateIDs{
+		getCmd:     DEVLINK_CMD_RATE_GET,
+		setCmd:     DEVLINK_CMD_RATE_SET,
+		newCmd:     DEVLINK_CMD_RATE_NEW,
+		delCmd:     DEVLINK_CMD_RATE_DEL,
+		typeAttr:   DEVLINK_ATTR_RATE_TYPE,
+		shareAttr:  DEVLINK_ATTR_RATE_TX_SHARE,
+		maxAttr:    DEVLINK_ATTR_RATE_TX_MAX,
+		nodeAttr:   DEVLINK_ATTR_RATE_NODE_NAME,
+		parentAttr: DEVLINK_ATTR_RATE_PARENT_NODE_NAME,
+	}
+}
+
+func (rate *DevlinkRate) parseAttributes(ids devlinkRateIDs, attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			rate.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			rate.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_PORT_INDEX:
+			rate.PortIndex = native.Uint32(a.Value)
+		case ids.typeAttr:
+			rate.Type = native.Uint16(a.Value)
+		case ids.nodeAttr:
+			rate.NodeName = nl.BytesToString(a.Value)
+		case ids.shareAttr:
+			rate.TxShare = native.Uint64(a.Value)
+		case ids.maxAttr:
+			rate.TxMax = native.Uint64(a.Value)
+		case ids.parentAttr:
+			rate.Parent = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_RATE_TX_PRIORITY:
+			rate.TxPriority = native.Uint32(a.Value)
+		case DEVLINK_ATTR_RATE_TX_WEIGHT:
+			rate.TxWeight = native.Uint32(a.Value)
+		}
+	}
+	return nil
+}
+
+func parseDevlinkRateList(Socket string, msgs [][]byte) ([]*DevlinkRate, error) {
+	ids := rateIDs(Socket)
+	rates := make([]*DevlinkRate, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		rate := &DevlinkRate{}
+		if err = rate.parseAttributes(ids, attrs); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// addRateSetAttrs adds the rate attributes to set to the request
+func addRateSetAttrs(Socket string, req *nl.NetlinkRequest, Attrs DevlinkRateSetAttrs) error {
+	if (Attrs.TxPriorityValid || Attrs.TxWeightValid) && Socket == GENL_MLXDEVM_NAME {
+		return fmt.Errorf("setting 'tx_priority' and 'tx_weight' is only supported by netlink family '%s'", GENL_DEVLINK_NAME)
+	}
+
+	ids := rateIDs(Socket)
+	if Attrs.TxShareValid {
+		req.AddData(nl.NewRtAttr(int(ids.shareAttr), nl.Uint64Attr(Attrs.TxShare)))
+	}
+	if Attrs.TxMaxValid {
+		req.AddData(nl.NewRtAttr(int(ids.maxAttr), nl.Uint64Attr(Attrs.TxMax)))
+	}
+	if Attrs.TxPriorityValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_RATE_TX_PRIORITY, nl.Uint32Attr(Attrs.TxPriority)))
+	}
+	if Attrs.TxWeightValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_RATE_TX_WEIGHT, nl.Uint32Attr(Attrs.TxWeight)))
+	}
+	if Attrs.ParentValid {
+		req.AddData(nl.NewRtAttr(int(ids.parentAttr), nl.ZeroTerminated(Attrs.Parent)))
+	}
+	return nil
+}
+
+func getDevlinkRate(Socket string, req *nl.NetlinkRequest) (*DevlinkRate, error) {
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := parseDevlinkRateList(Socket, respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return rates[0], nil
+}
+
+// DevlinkRateList returns all leaf rates and rate nodes of a devlink device,
+// otherwise returns an error code.
+// Equivalent to: `devlink port function rate show`
+func (h *Handle) DevlinkRateList(Socket string, Bus string, Device string) ([]*DevlinkRate, error) {
+	msgs, err := h.executeDeviceDump(Socket, rateIDs(Socket).getCmd, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkRateList(Socket, msgs)
+}
+
+// DevlinkRateList returns all leaf rates and rate nodes of a devlink device,
+// otherwise returns an error code.
+// Equivalent to: `devlink port function rate show`
+func DevlinkRateList(Socket string, Bus string, Device string) ([]*DevlinkRate, error) {
+	return pkgHandle.DevlinkRateList(Socket, Bus, Device)
+}
+
+// DevlinkPortRateGet returns the leaf rate of a devlink port, otherwise returns an error code.
+// Equivalent to: `devlink port function rate show $port`
+func (h *Handle) DevlinkPortRateGet(Socket string, Bus string, Device string, PortIndex uint32) (*DevlinkRate, error) {
+	_, req, err := h.createCmdReq(Socket, rateIDs(Socket).getCmd, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+
+	return getDevlinkRate(Socket, req)
+}
+
+// DevlinkPortRateGet returns the leaf rate of a devlink port, otherwise returns an error code.
+// Equivalent to: `devlink port function rate show $port`
+func DevlinkPortRateGet(Socket string, Bus string, Device string, PortIndex uint32) (*DevlinkRate, error) {
+	return pkgHandle.DevlinkPortRateGet(Socket, Bus, Device, PortIndex)
+}
+
+// DevlinkPortRateSet sets one or more leaf rate attributes of a devlink port,
+// including its parent node.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port function rate set $port tx_share 10mbit tx_max 100mbit parent group1`
+func (h *Handle) DevlinkPortRateSet(Socket string, Bus string, Device string, PortIndex uint32, Attrs DevlinkRateSetAttrs) error {
+	_, req, err := h.createCmdReq(Socket, rateIDs(Socket).setCmd, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+	if err = addRateSetAttrs(Socket, req, Attrs); err != nil {
+		return err
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkPortRateSet sets one or more leaf rate attributes of a devlink port,
+// including its parent node.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port function rate set $port tx_share 10mbit tx_max 100mbit parent group1`
+func DevlinkPortRateSet(Socket string, Bus string, Device string, PortIndex uint32, Attrs DevlinkRateSetAttrs) error {
+	return pkgHandle.DevlinkPortRateSet(Socket, Bus, Device, PortIndex, Attrs)
+}
+
+// DevlinkRateNodeGet returns a rate node of a devlink device, otherwise returns an error code.
+// Equivalent to: `devlink port function rate show $dev/group1`
+func (h *Handle) DevlinkRateNodeGet(Socket string, Bus string, Device string, NodeName string) (*DevlinkRate, error) {
+	_, req, err := h.createCmdReq(Socket, rateIDs(Socket).getCmd, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(int(rateIDs(Socket).nodeAttr), nl.ZeroTerminated(NodeName)))
+
+	return getDevlinkRate(Socket, req)
+}
+
+// DevlinkRateNodeGet returns a rate node of a devlink device, otherwise returns an error code.
+// Equivalent to: `devlink port function rate show $dev/group1`
+func DevlinkRateNodeGet(Socket string, Bus string, Device string, NodeName string) (*DevlinkRate, error) {
+	return pkgHandle.DevlinkRateNodeGet(Socket, Bus, Device, NodeName)
+}
+
+// DevlinkRateNodeSet sets one or more attributes of a rate node, including its parent node.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port function rate set $dev/group1 tx_max 1gbit parent group0`
+func (h *Handle) DevlinkRateNodeSet(Socket string, Bus string, Device string, NodeName string, Attrs DevlinkRateSetAttrs) error {
+	_, req, err := h.createCmdReq(Socket, rateIDs(Socket).setCmd, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(int(rateIDs(Socket).nodeAttr), nl.ZeroTerminated(NodeName)))
+	if err = addRateSetAttrs(Socket, req, Attrs); err != nil {
+		return err
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkRateNodeSet sets one or more attributes of a rate node, including its parent node.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port function rate set $dev/group1 tx_max 1gbit parent group0`
+func DevlinkRateNodeSet(Socket string, Bus string, Device string, NodeName string, Attrs DevlinkRateSetAttrs) error {
+	return pkgHandle.DevlinkRateNodeSet(Socket, Bus, Device, NodeName, Attrs)
+}
+
+// DevlinkRateNodeAdd creates a rate node with the given attributes.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port function rate add $dev/group1 tx_share 10mbit`
+func (h *Handle) DevlinkRateNodeAdd(Socket string, Bus string, Device string, NodeName string, Attrs DevlinkRateSetAttrs) error {
+	_, req, err := h.createCmdReq(Socket, rateIDs(Socket).newCmd, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(int(rateIDs(Socket).nodeAttr), nl.ZeroTerminated(NodeName)))
+	if err = addRateSetAttrs(Socket, req, Attrs); err != nil {
+		return err
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkRateNodeAdd creates a rate node with the given attributes.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port function rate add $dev/group1 tx_share 10mbit`
+func DevlinkRateNodeAdd(Socket string, Bus string, Device string, NodeName string, Attrs DevlinkRateSetAttrs) error {
+	return pkgHandle.DevlinkRateNodeAdd(Socket, Bus, Device, NodeName, Attrs)
+}
+
+// DevlinkRateNodeDel deletes a rate node.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port function rate del $dev/group1`
+func (h *Handle) DevlinkRateNodeDel(Socket string, Bus string, Device string, NodeName string) error {
+	_, req, err := h.createCmdReq(Socket, rateIDs(Socket).delCmd, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(int(rateIDs(Socket).nodeAttr), nl.ZeroTerminated(NodeName)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkRateNodeDel deletes a rate node.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port function rate del $dev/group1`
+func DevlinkRateNodeDel(Socket string, Bus string, Device string, NodeName string) error {
+	return pkgHandle.DevlinkRateNodeDel(Socket, Bus, Device, NodeName)
+}
+
+func trapStringToAction(actionName string) (uint8, error) {
+	switch actionName {
+	case "drop":
+		return DEVLINK_TRAP_ACTION_DROP, nil
+	case "trap":
+		return DEVLINK_TRAP_ACTION_TRAP, nil
+	case "mirror":
+		return DEVLINK_TRAP_ACTION_MIRROR, nil
+	default:
+		return 0xff, fmt.Errorf("invalid trap action")
+	}
+}
+
+func parseTrapAction(action uint8) string {
+	var trapAction = map[uint8]string{
+		DEVLINK_TRAP_ACTION_DROP:   "drop",
+		DEVLINK_TRAP_ACTION_TRAP:   "trap",
+		DEVLINK_TRAP_ACTION_MIRROR: "mirror",
+	}
+	if trapAction[action] == "" {
+		return "unknown"
+	}
+	return trapAction[action]
+}
+
+func parseTrapType(trapType uint8) string {
+	var trapTypes = map[uint8]string{
+		DEVLINK_TRAP_TYPE_DROP:      "drop",
+		DEVLINK_TRAP_TYPE_EXCEPTION: "exception",
+		DEVLINK_TRAP_TYPE_CONTROL:   "control",
+	}
+	if trapTypes[trapType] == "" {
+		return "unknown"
+	}
+	return trapTypes[trapType]
+}
+
+func parseTrapMetadata(data []byte) ([]string, error) {
+	var trapMetadata = map[uint16]string{
+		DEVLINK_ATTR_TRAP_METADATA_TYPE_IN_PORT:   "input_port",
+		DEVLINK_ATTR_TRAP_METADATA_TYPE_FA_COOKIE: "flow_action_cookie",
+	}
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return nil, err
+	}
+	metadata := make([]string, 0, len(attrs))
+	for _, a := range attrs {
+		name, ok := trapMetadata[a.Attr.Type&nl.NLA_TYPE_MASK]
+		if !ok {
+			name = "unknown"
+		}
+		metadata = append(metadata, name)
+	}
+	return metadata, nil
+}
+
+func (stats *DevlinkTrapStats) parseAttributes(data []byte) error {
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return err
+	}
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_STATS_RX_PACKETS:
+			stats.RxPackets = native.Uint64(a.Value)
+		case DEVLINK_ATTR_STATS_RX_BYTES:
+			stats.RxBytes = native.Uint64(a.Value)
+		case DEVLINK_ATTR_STATS_RX_DROPPED:
+			stats.RxDropped = native.Uint64(a.Value)
+		}
+	}
+	return nil
+}
+
+func (trap *DevlinkTrap) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		var err error
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			trap.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			trap.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_TRAP_NAME:
+			trap.Name = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_TRAP_GROUP_NAME:
+			trap.Group = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_TRAP_TYPE:
+			trap.Type = parseTrapType(uint8(a.Value[0]))
+		case DEVLINK_ATTR_TRAP_ACTION:
+			trap.Action = parseTrapAction(uint8(a.Value[0]))
+		case DEVLINK_ATTR_TRAP_GENERIC:
+			trap.Generic = true
+		case DEVLINK_ATTR_TRAP_METADATA:
+			trap.Metadata, err = parseTrapMetadata(a.Value)
+		case DEVLINK_ATTR_STATS:
+			err = trap.Stats.parseAttributes(a.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (group *DevlinkTrapGroup) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			group.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			group.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_TRAP_GROUP_NAME:
+			group.Name = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_TRAP_GENERIC:
+			group.Generic = true
+		case DEVLINK_ATTR_TRAP_POLICER_ID:
+			group.PolicerID = native.Uint32(a.Value)
+			group.PolicerIDValid = true
+		case DEVLINK_ATTR_STATS:
+			if err := group.Stats.parseAttributes(a.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (policer *DevlinkTrapPolicer) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			policer.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			policer.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_TRAP_POLICER_ID:
+			policer.ID = native.Uint32(a.Value)
+		case DEVLINK_ATTR_TRAP_POLICER_RATE:
+			policer.Rate = native.Uint64(a.Value)
+		case DEVLINK_ATTR_TRAP_POLICER_BURST:
+			policer.Burst = native.Uint64(a.Value)
+		case DEVLINK_ATTR_STATS:
+			if err := policer.Stats.parseAttributes(a.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseDevlinkTrapList(msgs [][]byte) ([]*DevlinkTrap, error) {
+	traps := make([]*DevlinkTrap, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		trap := &DevlinkTrap{}
+		if err = trap.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		traps = append(traps, trap)
+	}
+	return traps, nil
+}
+
+func parseDevlinkTrapGroupList(msgs [][]byte) ([]*DevlinkTrapGroup, error) {
+	groups := make([]*DevlinkTrapGroup, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		group := &DevlinkTrapGroup{}
+		if err = group.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func parseDevlinkTrapPolicerList(msgs [][]byte) ([]*DevlinkTrapPolicer, error) {
+	policers := make([]*DevlinkTrapPolicer, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		policer := &DevlinkTrapPolicer{}
+		if err = policer.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		policers = append(policers, policer)
+	}
+	return policers, nil
+}
+
+// DevlinkTrapList returns all packet traps of a devlink device with their
+// statistics, otherwise returns an error code.
+// Equivalent to: `devlink -s trap show $dev`
+func (h *Handle) DevlinkTrapList(Socket string, Bus string, Device string) ([]*DevlinkTrap, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_TRAP_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkTrapList(msgs)
+}
+
+// DevlinkTrapList returns all packet traps of a devlink device with their
+// statistics, otherwise returns an error code.
+// Equivalent to: `devlink -s trap show $dev`
+func DevlinkTrapList(Socket string, Bus string, Device string) ([]*DevlinkTrap, error) {
+	return pkgHandle.DevlinkTrapList(Socket, Bus, Device)
+}
+
+// DevlinkTrapGet returns a packet trap with its statistics, otherwise returns an error code.
+// Equivalent to: `devlink -s trap show $dev trap source_mac_is_multicast`
+func (h *Handle) DevlinkTrapGet(Socket string, Bus string, Device string, Name string) (*DevlinkTrap, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_TRAP_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_NAME, nl.ZeroTerminated(Name)))
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	traps, err := parseDevlinkTrapList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return traps[0], nil
+}
+
+// DevlinkTrapGet returns a packet trap with its statistics, otherwise returns an error code.
+// Equivalent to: `devlink -s trap show $dev trap source_mac_is_multicast`
+func DevlinkTrapGet(Socket string, Bus string, Device string, Name string) (*DevlinkTrap, error) {
+	return pkgHandle.DevlinkTrapGet(Socket, Bus, Device, Name)
+}
+
+// DevlinkTrapSet sets the action of a packet trap to drop, trap or mirror.
+// It returns nil on success or error code.
+// Equivalent to: `devlink trap set $dev trap source_mac_is_multicast action trap`
+func (h *Handle) DevlinkTrapSet(Socket string, Bus string, Device string, Name string, Action string) error {
+	action, err := trapStringToAction(Action)
+	if err != nil {
+		return err
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_TRAP_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_NAME, nl.ZeroTerminated(Name)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_ACTION, nl.Uint8Attr(action)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkTrapSet sets the action of a packet trap to drop, trap or mirror.
+// It returns nil on success or error code.
+// Equivalent to: `devlink trap set $dev trap source_mac_is_multicast action trap`
+func DevlinkTrapSet(Socket string, Bus string, Device string, Name string, Action string) error {
+	return pkgHandle.DevlinkTrapSet(Socket, Bus, Device, Name, Action)
+}
+
+// DevlinkTrapGroupList returns all packet trap groups of a devlink device
+// with their statistics, otherwise returns an error code.
+// Equivalent to: `devlink -s trap group show $dev`
+func (h *Handle) DevlinkTrapGroupList(Socket string, Bus string, Device string) ([]*DevlinkTrapGroup, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_TRAP_GROUP_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkTrapGroupList(msgs)
+}
+
+// DevlinkTrapGroupList returns all packet trap groups of a devlink device
+// with their statistics, otherwise returns an error code.
+// Equivalent to: `devlink -s trap group show $dev`
+func DevlinkTrapGroupList(Socket string, Bus string, Device string) ([]*DevlinkTrapGroup, error) {
+	return pkgHandle.DevlinkTrapGroupList(Socket, Bus, Device)
+}
+
+// DevlinkTrapGroupGet returns a packet trap group with its statistics,
+// otherwise returns an error code.
+// Equivalent to: `devlink -s trap group show $dev group l2_drops`
+func (h *Handle) DevlinkTrapGroupGet(Socket string, Bus string, Device string, Name string) (*DevlinkTrapGroup, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_TRAP_GROUP_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_GROUP_NAME, nl.ZeroTerminated(Name)))
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := parseDevlinkTrapGroupList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return groups[0], nil
+}
+
+// DevlinkTrapGroupGet returns a packet trap group with its statistics,
+// otherwise returns an error code.
+// Equivalent to: `devlink -s trap group show $dev group l2_drops`
+func DevlinkTrapGroupGet(Socket string, Bus string, Device string, Name string) (*DevlinkTrapGroup, error) {
+	return pkgHandle.DevlinkTrapGroupGet(Socket, Bus, Device, Name)
+}
+
+// DevlinkTrapGroupSet sets the action of all traps of a packet trap group
+// and binds the group to a policer.
+// It returns nil on success or error code.
+// Equivalent to: `devlink trap group set $dev group l2_drops action trap policer 1`
+func (h *Handle) DevlinkTrapGroupSet(Socket string, Bus string, Device string, Name string, Attrs DevlinkTrapGroupSetAttrs) error {
+	if Attrs.Action == "" && !Attrs.PolicerIDValid {
+		return fmt.Errorf("no trap group attribute to set")
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_TRAP_GROUP_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_GROUP_NAME, nl.ZeroTerminated(Name)))
+	if Attrs.Action != "" {
+		action, err := trapStringToAction(Attrs.Action)
+		if err != nil {
+			return err
+		}
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_ACTION, nl.Uint8Attr(action)))
+	}
+	if Attrs.PolicerIDValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_POLICER_ID, nl.Uint32Attr(Attrs.PolicerID)))
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkTrapGroupSet sets the action of all traps of a packet trap group
+// and binds the group to a policer.
+// It returns nil on success or error code.
+// Equivalent to: `devlink trap group set $dev group l2_drops action trap policer 1`
+func DevlinkTrapGroupSet(Socket string, Bus string, Device string, Name string, Attrs DevlinkTrapGroupSetAttrs) error {
+	return pkgHandle.DevlinkTrapGroupSet(Socket, Bus, Device, Name, Attrs)
+}
+
+// DevlinkTrapPolicerList returns all packet trap policers of a devlink device
+// with their statistics, otherwise returns an error code.
+// Equivalent to: `devlink -s trap policer show $dev`
+func (h *Handle) DevlinkTrapPolicerList(Socket string, Bus string, Device string) ([]*DevlinkTrapPolicer, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_TRAP_POLICER_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkTrapPolicerList(msgs)
+}
+
+// DevlinkTrapPolicerList returns all packet trap policers of a devlink device
+// with their statistics, otherwise returns an error code.
+// Equivalent to: `devlink -s trap policer show $dev`
+func DevlinkTrapPolicerList(Socket string, Bus string, Device string) ([]*DevlinkTrapPolicer, error) {
+	return pkgHandle.DevlinkTrapPolicerList(Socket, Bus, Device)
+}
+
+// DevlinkTrapPolicerGet returns a packet trap policer with its statistics,
+// otherwise returns an error code.
+// Equivalent to: `devlink -s trap policer show $dev policer 1`
+func (h *Handle) DevlinkTrapPolicerGet(Socket string, Bus string, Device string, ID uint32) (*DevlinkTrapPolicer, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_TRAP_POLICER_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_POLICER_ID, nl.Uint32Attr(ID)))
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	policers, err := parseDevlinkTrapPolicerList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return policers[0], nil
+}
+
+// DevlinkTrapPolicerGet returns a packet trap policer with its statistics,
+// otherwise returns an error code.
+// Equivalent to: `devlink -s trap policer show $dev policer 1`
+func DevlinkTrapPolicerGet(Socket string, Bus string, Device string, ID uint32) (*DevlinkTrapPolicer, error) {
+	return pkgHandle.DevlinkTrapPolicerGet(Socket, Bus, Device, ID)
+}
+
+// DevlinkTrapPolicerSet sets the rate and burst size of a packet trap policer.
+// It returns nil on success or error code.
+// Equivalent to: `devlink trap policer set $dev policer 1 rate 1000 burst 128`
+func (h *Handle) DevlinkTrapPolicerSet(Socket string, Bus string, Device string, ID uint32, Attrs DevlinkTrapPolicerSetAttrs) error {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_TRAP_POLICER_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_POLICER_ID, nl.Uint32Attr(ID)))
+	if Attrs.RateValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_POLICER_RATE, nl.Uint64Attr(Attrs.Rate)))
+	}
+	if Attrs.BurstValid {
+		req.AddData(nl.NewRtAttr(DEVLINK_ATTR_TRAP_POLICER_BURST, nl.Uint64Attr(Attrs.Burst)))
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkTrapPolicerSet sets the rate and burst size of a packet trap policer.
+// It returns nil on success or error code.
+// Equivalent to: `devlink trap policer set $dev policer 1 rate 1000 burst 128`
+func DevlinkTrapPolicerSet(Socket string, Bus string, Device string, ID uint32, Attrs DevlinkTrapPolicerSetAttrs) error {
+	return pkgHandle.DevlinkTrapPolicerSet(Socket, Bus, Device, ID, Attrs)
+}
+
+func sbStringToPoolType(typeName string) (uint8, error) {
+	switch typeName {
+	case "ingress":
+		return DEVLINK_SB_POOL_TYPE_INGRESS, nil
+	case "egress":
+		return DEVLINK_SB_POOL_TYPE_EGRESS, nil
+	default:
+		return 0xff, fmt.Errorf("invalid sb pool type")
+	}
+}
+
+func sbStringToThresholdType(typeName string) (uint8, error) {
+	switch typeName {
+	case "static":
+		return DEVLINK_SB_THRESHOLD_TYPE_STATIC, nil
+	case "dynamic":
+		return DEVLINK_SB_THRESHOLD_TYPE_DYNAMIC, nil
+	default:
+		return 0xff, fmt.Errorf("invalid sb threshold type")
+	}
+}
+
+func parseSbPoolType(poolType uint8) string {
+	var poolTypes = map[uint8]string{
+		DEVLINK_SB_POOL_TYPE_INGRESS: "ingress",
+		DEVLINK_SB_POOL_TYPE_EGRESS:  "egress",
+	}
+	if poolTypes[poolType] == "" {
+		return "unknown"
+	}
+	return poolTypes[poolType]
+}
+
+func parseSbThresholdType(thresholdType uint8) string {
+	var thresholdTypes = map[uint8]string{
+		DEVLINK_SB_THRESHOLD_TYPE_STATIC:  "static",
+		DEVLINK_SB_THRESHOLD_TYPE_DYNAMIC: "dynamic",
+	}
+	if thresholdTypes[thresholdType] == "" {
+		return "unknown"
+	}
+	return thresholdTypes[thresholdType]
+}
+
+func (sb *DevlinkSb) parseAttributes(attrs []syscall.NetlinkRouteAttr) {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			sb.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			sb.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_SB_INDEX:
+			sb.Index = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_SIZE:
+			sb.Size = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_INGRESS_POOL_COUNT:
+			sb.IngressPoolCount = native.Uint16(a.Value)
+		case DEVLINK_ATTR_SB_EGRESS_POOL_COUNT:
+			sb.EgressPoolCount = native.Uint16(a.Value)
+		case DEVLINK_ATTR_SB_INGRESS_TC_COUNT:
+			sb.IngressTcCount = native.Uint16(a.Value)
+		case DEVLINK_ATTR_SB_EGRESS_TC_COUNT:
+			sb.EgressTcCount = native.Uint16(a.Value)
+		}
+	}
+}
+
+func (pool *DevlinkSbPool) parseAttributes(attrs []syscall.NetlinkRouteAttr) {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			pool.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			pool.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_SB_INDEX:
+			pool.SbIndex = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_POOL_INDEX:
+			pool.Index = native.Uint16(a.Value)
+		case DEVLINK_ATTR_SB_POOL_TYPE:
+			pool.Type = parseSbPoolType(uint8(a.Value[0]))
+		case DEVLINK_ATTR_SB_POOL_SIZE:
+			pool.Size = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_POOL_THRESHOLD_TYPE:
+			pool.ThresholdType = parseSbThresholdType(uint8(a.Value[0]))
+		case DEVLINK_ATTR_SB_POOL_CELL_SIZE:
+			pool.CellSize = native.Uint32(a.Value)
+		}
+	}
+}
+
+func (pp *DevlinkSbPortPool) parseAttributes(attrs []syscall.NetlinkRouteAttr) {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			pp.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			pp.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_PORT_INDEX:
+			pp.PortIndex = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_INDEX:
+			pp.SbIndex = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_POOL_INDEX:
+			pp.PoolIndex = native.Uint16(a.Value)
+		case DEVLINK_ATTR_SB_THRESHOLD:
+			pp.Threshold = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_OCC_CUR:
+			pp.OccCur = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_OCC_MAX:
+			pp.OccMax = native.Uint32(a.Value)
+		}
+	}
+}
+
+func (tc *DevlinkSbTcBind) parseAttributes(attrs []syscall.NetlinkRouteAttr) {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			tc.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			tc.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_PORT_INDEX:
+			tc.PortIndex = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_INDEX:
+			tc.SbIndex = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_TC_INDEX:
+			tc.TcIndex = native.Uint16(a.Value)
+		case DEVLINK_ATTR_SB_POOL_TYPE:
+			tc.Type = parseSbPoolType(uint8(a.Value[0]))
+		case DEVLINK_ATTR_SB_POOL_INDEX:
+			tc.PoolIndex = native.Uint16(a.Value)
+		case DEVLINK_ATTR_SB_THRESHOLD:
+			tc.Threshold = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_OCC_CUR:
+			tc.OccCur = native.Uint32(a.Value)
+		case DEVLINK_ATTR_SB_OCC_MAX:
+			tc.OccMax = native.Uint32(a.Value)
+		}
+	}
+}
+
+func parseDevlinkSbList(msgs [][]byte) ([]*DevlinkSb, error) {
+	sbs := make([]*DevlinkSb, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		sb := &DevlinkSb{}
+		sb.parseAttributes(attrs)
+		sbs = append(sbs, sb)
+	}
+	return sbs, nil
+}
+
+func parseDevlinkSbPoolList(msgs [][]byte) ([]*DevlinkSbPool, error) {
+	pools := make([]*DevlinkSbPool, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		pool := &DevlinkSbPool{}
+		pool.parseAttributes(attrs)
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+func parseDevlinkSbPortPoolList(msgs [][]byte) ([]*DevlinkSbPortPool, error) {
+	pps := make([]*DevlinkSbPortPool, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		pp := &DevlinkSbPortPool{}
+		pp.parseAttributes(attrs)
+		pps = append(pps, pp)
+	}
+	return pps, nil
+}
+
+func parseDevlinkSbTcBindList(msgs [][]byte) ([]*DevlinkSbTcBind, error) {
+	tcs := make([]*DevlinkSbTcBind, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		tc := &DevlinkSbTcBind{}
+		tc.parseAttributes(attrs)
+		tcs = append(tcs, tc)
+	}
+	return tcs, nil
+}
+
+// DevlinkSbList returns all shared buffers of a devlink device,
+// otherwise returns an error code.
+// Equivalent to: `devlink sb show $dev`
+func (h *Handle) DevlinkSbList(Socket string, Bus string, Device string) ([]*DevlinkSb, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_SB_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkSbList(msgs)
+}
+
+// DevlinkSbList returns all shared buffers of a devlink device,
+// otherwise returns an error code.
+// Equivalent to: `devlink sb show $dev`
+func DevlinkSbList(Socket string, Bus string, Device string) ([]*DevlinkSb, error) {
+	return pkgHandle.DevlinkSbList(Socket, Bus, Device)
+}
+
+// DevlinkSbPoolList returns all pools of all shared buffers of a devlink
+// device, otherwise returns an error code.
+// Equivalent to: `devlink sb pool show $dev`
+func (h *Handle) DevlinkSbPoolList(Socket string, Bus string, Device string) ([]*DevlinkSbPool, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_SB_POOL_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkSbPoolList(msgs)
+}
+
+// DevlinkSbPoolList returns all pools of all shared buffers of a devlink
+// device, otherwise returns an error code.
+// Equivalent to: `devlink sb pool show $dev`
+func DevlinkSbPoolList(Socket string, Bus string, Device string) ([]*DevlinkSbPool, error) {
+	return pkgHandle.DevlinkSbPoolList(Socket, Bus, Device)
+}
+
+// DevlinkSbPoolGet returns a shared buffer pool, otherwise returns an error code.
+// Equivalent to: `devlink sb pool show $dev sb 0 pool 0`
+func (h *Handle) DevlinkSbPoolGet(Socket string, Bus string, Device string, SbIndex uint32, PoolIndex uint16) (*DevlinkSbPool, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SB_POOL_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_INDEX, nl.Uint32Attr(SbIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_INDEX, nl.Uint16Attr(PoolIndex)))
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := parseDevlinkSbPoolList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return pools[0], nil
+}
+
+// DevlinkSbPoolGet returns a shared buffer pool, otherwise returns an error code.
+// Equivalent to: `devlink sb pool show $dev sb 0 pool 0`
+func DevlinkSbPoolGet(Socket string, Bus string, Device string, SbIndex uint32, PoolIndex uint16) (*DevlinkSbPool, error) {
+	return pkgHandle.DevlinkSbPoolGet(Socket, Bus, Device, SbIndex, PoolIndex)
+}
+
+// DevlinkSbPoolSet sets the size and the threshold type (static or dynamic)
+// of a shared buffer pool.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb pool set $dev sb 0 pool 0 size 1024 thtype static`
+func (h *Handle) DevlinkSbPoolSet(Socket string, Bus string, Device string, SbIndex uint32, PoolIndex uint16, Size uint32, ThresholdType string) error {
+	thType, err := sbStringToThresholdType(ThresholdType)
+	if err != nil {
+		return err
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SB_POOL_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_INDEX, nl.Uint32Attr(SbIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_INDEX, nl.Uint16Attr(PoolIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_SIZE, nl.Uint32Attr(Size)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_THRESHOLD_TYPE, nl.Uint8Attr(thType)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkSbPoolSet sets the size and the threshold type (static or dynamic)
+// of a shared buffer pool.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb pool set $dev sb 0 pool 0 size 1024 thtype static`
+func DevlinkSbPoolSet(Socket string, Bus string, Device string, SbIndex uint32, PoolIndex uint16, Size uint32, ThresholdType string) error {
+	return pkgHandle.DevlinkSbPoolSet(Socket, Bus, Device, SbIndex, PoolIndex, Size, ThresholdType)
+}
+
+// DevlinkSbPortPoolList returns the pool thresholds and occupancy of all
+// ports of a devlink device, otherwise returns an error code.
+// Equivalent to: `devlink sb port pool show`
+func (h *Handle) DevlinkSbPortPoolList(Socket string, Bus string, Device string) ([]*DevlinkSbPortPool, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_SB_PORT_POOL_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkSbPortPoolList(msgs)
+}
+
+// DevlinkSbPortPoolList returns the pool thresholds and occupancy of all
+// ports of a devlink device, otherwise returns an error code.
+// Equivalent to: `devlink sb port pool show`
+func DevlinkSbPortPoolList(Socket string, Bus string, Device string) ([]*DevlinkSbPortPool, error) {
+	return pkgHandle.DevlinkSbPortPoolList(Socket, Bus, Device)
+}
+
+// DevlinkSbPortPoolGet returns the threshold and occupancy of a shared buffer
+// pool for a devlink port, otherwise returns an error code.
+// Equivalent to: `devlink sb port pool show $dev/$port sb 0 pool 0`
+func (h *Handle) DevlinkSbPortPoolGet(Socket string, Bus string, Device string, PortIndex uint32, SbIndex uint32, PoolIndex uint16) (*DevlinkSbPortPool, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SB_PORT_POOL_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_INDEX, nl.Uint32Attr(SbIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_INDEX, nl.Uint16Attr(PoolIndex)))
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	pps, err := parseDevlinkSbPortPoolList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return pps[0], nil
+}
+
+// DevlinkSbPortPoolGet returns the threshold and occupancy of a shared buffer
+// pool for a devlink port, otherwise returns an error code.
+// Equivalent to: `devlink sb port pool show $dev/$port sb 0 pool 0`
+func DevlinkSbPortPoolGet(Socket string, Bus string, Device string, PortIndex uint32, SbIndex uint32, PoolIndex uint16) (*DevlinkSbPortPool, error) {
+	return pkgHandle.DevlinkSbPortPoolGet(Socket, Bus, Device, PortIndex, SbIndex, PoolIndex)
+}
+
+// DevlinkSbPortPoolSet sets the threshold of a shared buffer pool for a devlink port.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb port pool set $dev/$port sb 0 pool 0 th 15`
+func (h *Handle) DevlinkSbPortPoolSet(Socket string, Bus string, Device string, PortIndex uint32, SbIndex uint32, PoolIndex uint16, Threshold uint32) error {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SB_PORT_POOL_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_INDEX, nl.Uint32Attr(SbIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_INDEX, nl.Uint16Attr(PoolIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_THRESHOLD, nl.Uint32Attr(Threshold)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkSbPortPoolSet sets the threshold of a shared buffer pool for a devlink port.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb port pool set $dev/$port sb 0 pool 0 th 15`
+func DevlinkSbPortPoolSet(Socket string, Bus string, Device string, PortIndex uint32, SbIndex uint32, PoolIndex uint16, Threshold uint32) error {
+	return pkgHandle.DevlinkSbPortPoolSet(Socket, Bus, Device, PortIndex, SbIndex, PoolIndex, Threshold)
+}
+
+// DevlinkSbTcBindList returns the traffic class pool bindings and occupancy
+// of all ports of a devlink device, otherwise returns an error code.
+// Equivalent to: `devlink sb tc bind show`
+func (h *Handle) DevlinkSbTcBindList(Socket string, Bus string, Device string) ([]*DevlinkSbTcBind, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_SB_TC_POOL_BIND_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkSbTcBindList(msgs)
+}
+
+// DevlinkSbTcBindList returns the traffic class pool bindings and occupancy
+// of all ports of a devlink device, otherwise returns an error code.
+// Equivalent to: `devlink sb tc bind show`
+func DevlinkSbTcBindList(Socket string, Bus string, Device string) ([]*DevlinkSbTcBind, error) {
+	return pkgHandle.DevlinkSbTcBindList(Socket, Bus, Device)
+}
+
+// DevlinkSbTcBindGet returns the pool binding and occupancy of an ingress or
+// egress traffic class of a devlink port, otherwise returns an error code.
+// Equivalent to: `devlink sb tc bind show $dev/$port sb 0 tc 0 type ingress`
+func (h *Handle) DevlinkSbTcBindGet(Socket string, Bus string, Device string, PortIndex uint32, SbIndex uint32, TcIndex uint16, Type string) (*DevlinkSbTcBind, error) {
+	poolType, err := sbStringToPoolType(Type)
+	if err != nil {
+		return nil, err
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SB_TC_POOL_BIND_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_INDEX, nl.Uint32Attr(SbIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_TC_INDEX, nl.Uint16Attr(TcIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_TYPE, nl.Uint8Attr(poolType)))
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	tcs, err := parseDevlinkSbTcBindList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return tcs[0], nil
+}
+
+// DevlinkSbTcBindGet returns the pool binding and occupancy of an ingress or
+// egress traffic class of a devlink port, otherwise returns an error code.
+// Equivalent to: `devlink sb tc bind show $dev/$port sb 0 tc 0 type ingress`
+func DevlinkSbTcBindGet(Socket string, Bus string, Device string, PortIndex uint32, SbIndex uint32, TcIndex uint16, Type string) (*DevlinkSbTcBind, error) {
+	return pkgHandle.DevlinkSbTcBindGet(Socket, Bus, Device, PortIndex, SbIndex, TcIndex, Type)
+}
+
+// DevlinkSbTcBindSet binds an ingress or egress traffic class of a devlink
+// port to a shared buffer pool with the given threshold.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb tc bind set $dev/$port sb 0 tc 0 type ingress pool 0 th 9`
+func (h *Handle) DevlinkSbTcBindSet(Socket string, Bus string, Device string, PortIndex uint32, SbIndex uint32, TcIndex uint16, Type string, PoolIndex uint16, Threshold uint32) error {
+	poolType, err := sbStringToPoolType(Type)
+	if err != nil {
+		return err
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SB_TC_POOL_BIND_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(PortIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_INDEX, nl.Uint32Attr(SbIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_TC_INDEX, nl.Uint16Attr(TcIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_TYPE, nl.Uint8Attr(poolType)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_POOL_INDEX, nl.Uint16Attr(PoolIndex)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_THRESHOLD, nl.Uint32Attr(Threshold)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkSbTcBindSet binds an ingress or egress traffic class of a devlink
+// port to a shared buffer pool with the given threshold.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb tc bind set $dev/$port sb 0 tc 0 type ingress pool 0 th 9`
+func DevlinkSbTcBindSet(Socket string, Bus string, Device string, PortIndex uint32, SbIndex uint32, TcIndex uint16, Type string, PoolIndex uint16, Threshold uint32) error {
+	return pkgHandle.DevlinkSbTcBindSet(Socket, Bus, Device, PortIndex, SbIndex, TcIndex, Type, PoolIndex, Threshold)
+}
+
+// DevlinkSbOccSnapshot takes an occupancy snapshot of a shared buffer. The
+// snapshot values are reported as OccCur and OccMax of the port pool and
+// traffic class bindings.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb occupancy snapshot $dev sb 0`
+func (h *Handle) DevlinkSbOccSnapshot(Socket string, Bus string, Device string, SbIndex uint32) error {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SB_OCC_SNAPSHOT, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_INDEX, nl.Uint32Attr(SbIndex)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkSbOccSnapshot takes an occupancy snapshot of a shared buffer. The
+// snapshot values are reported as OccCur and OccMax of the port pool and
+// traffic class bindings.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb occupancy snapshot $dev sb 0`
+func DevlinkSbOccSnapshot(Socket string, Bus string, Device string, SbIndex uint32) error {
+	return pkgHandle.DevlinkSbOccSnapshot(Socket, Bus, Device, SbIndex)
+}
+
+// DevlinkSbOccMaxClear clears the maximum occupancy watermarks of a shared buffer.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb occupancy clearmax $dev sb 0`
+func (h *Handle) DevlinkSbOccMaxClear(Socket string, Bus string, Device string, SbIndex uint32) error {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SB_OCC_MAX_CLEAR, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_SB_INDEX, nl.Uint32Attr(SbIndex)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkSbOccMaxClear clears the maximum occupancy watermarks of a shared buffer.
+// It returns nil on success or error code.
+// Equivalent to: `devlink sb occupancy clearmax $dev sb 0`
+func DevlinkSbOccMaxClear(Socket string, Bus string, Device string, SbIndex uint32) error {
+	return pkgHandle.DevlinkSbOccMaxClear(Socket, Bus, Device, SbIndex)
+}
+
+func parseDevlinkDevParamValue(paramType uint8, data []byte) (DevlinkDevParamValue, error) {
+	value := DevlinkDevParamValue{Type: paramType}
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return value, err
+	}
+	dataValid := false
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_PARAM_VALUE_CMODE:
+			value.CMode = uint8(a.Value[0])
+		case DEVLINK_ATTR_PARAM_VALUE_DATA:
+			value.Data = a.Value
+			dataValid = true
+		}
+	}
+	if paramType == MNL_TYPE_FLAG {
+		// a flag value is true when the data attribute is present
+		value.Data = []byte{0}
+		if dataValid {
+			value.Data[0] = 1
+		}
+	}
+	return value, nil
+}
+
+func (param *DevlinkDevParamInfo) parseParamAttrs(data []byte) error {
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return err
+	}
+	var valuesList []byte
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_PARAM_NAME:
+			param.Name = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_PARAM_GENERIC:
+			param.Generic = true
+		case DEVLINK_ATTR_PARAM_TYPE:
+			param.Type = uint8(a.Value[0])
+		case DEVLINK_ATTR_PARAM_VALUES_LIST:
+			valuesList = a.Value
+		}
+	}
+	if valuesList == nil {
+		return nil
+	}
+
+	// the value data can only be decoded once the parameter type is known
+	values, err := nl.ParseRouteAttr(valuesList)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if v.Attr.Type&nl.NLA_TYPE_MASK != DEVLINK_ATTR_PARAM_VALUE {
+			continue
+		}
+		value, err := parseDevlinkDevParamValue(param.Type, v.Value)
+		if err != nil {
+			return err
+		}
+		param.Values = append(param.Values, value)
+	}
+	return nil
+}
+
+func (param *DevlinkDevParamInfo) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			param.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			param.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_PORT_INDEX:
+			param.PortIndex = native.Uint32(a.Value)
+			param.PortIndexValid = true
+		case DEVLINK_ATTR_PARAM:
+			if err := param.parseParamAttrs(a.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseDevlinkDevParamList(msgs [][]byte) ([]*DevlinkDevParamInfo, error) {
+	params := make([]*DevlinkDevParamInfo, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		param := &DevlinkDevParamInfo{}
+		if err = param.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// DevlinkDevParamList returns all parameters of a devlink device with the
+// values of every supported configuration mode, otherwise returns an error code.
+// Equivalent to: `devlink dev param show $dev`
+func (h *Handle) DevlinkDevParamList(Socket string, Bus string, Device string) ([]*DevlinkDevParamInfo, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_PARAM_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkDevParamList(msgs)
+}
+
+// DevlinkDevParamList returns all parameters of a devlink device with the
+// values of every supported configuration mode, otherwise returns an error code.
+// Equivalent to: `devlink dev param show $dev`
+func DevlinkDevParamList(Socket string, Bus string, Device string) ([]*DevlinkDevParamInfo, error) {
+	return pkgHandle.DevlinkDevParamList(Socket, Bus, Device)
+}
+
+// Value returns the value of the parameter in its reported configuration mode
+func (param *DevlinkDevParam) Value() DevlinkDevParamValue {
+	return DevlinkDevParamValue{
+		CMode: param.CMode,
+		Type:  uint8(param.Attribute.Type),
+		Data:  param.Attribute.Value,
+	}
+}
+
+func (v DevlinkDevParamValue) checkType(paramType uint8, size int) error {
+	if v.Type != paramType {
+		return fmt.Errorf("parameter type %d is not %d", v.Type, paramType)
+	}
+	if len(v.Data) < size {
+		return fmt.Errorf("parameter value too short")
+	}
+	return nil
+}
+
+// Uint8 returns the value of a u8 parameter
+func (v DevlinkDevParamValue) Uint8() (uint8, error) {
+	if err := v.checkType(MNL_TYPE_U8, 1); err != nil {
+		return 0, err
+	}
+	return v.Data[0], nil
+}
+
+// Uint16 returns the value of a u16 parameter
+func (v DevlinkDevParamValue) Uint16() (uint16, error) {
+	if err := v.checkType(MNL_TYPE_U16, 2); err != nil {
+		return 0, err
+	}
+	return native.Uint16(v.Data), nil
+}
+
+// Uint32 returns the value of a u32 parameter
+func (v DevlinkDevParamValue) Uint32() (uint32, error) {
+	if err := v.checkType(MNL_TYPE_U32, 4); err != nil {
+		return 0, err
+	}
+	return native.Uint32(v.Data), nil
+}
+
+// Uint64 returns the value of a u64 parameter
+func (v DevlinkDevParamValue) Uint64() (uint64, error) {
+	if err := v.checkType(MNL_TYPE_U64, 8); err != nil {
+		return 0, err
+	}
+	return native.Uint64(v.Data), nil
+}
+
+// StringValue returns the value of a string parameter
+func (v DevlinkDevParamValue) StringValue() (string, error) {
+	if err := v.checkType(MNL_TYPE_STRING, 0); err != nil {
+		return "", err
+	}
+	return nl.BytesToString(v.Data), nil
+}
+
+// Bool returns the value of a flag parameter
+func (v DevlinkDevParamValue) Bool() (bool, error) {
+	if err := v.checkType(MNL_TYPE_FLAG, 1); err != nil {
+		return false, err
+	}
+	return v.Data[0] != 0, nil
+}
+
+// Value returns the parameter value as uint8, uint16, uint32, uint64, string
+// or bool according to its type
+func (v DevlinkDevParamValue) Value() (any, error) {
+	switch v.Type {
+	case MNL_TYPE_U8:
+		return v.Uint8()
+	case MNL_TYPE_U16:
+		return v.Uint16()
+	case MNL_TYPE_U32:
+		return v.Uint32()
+	case MNL_TYPE_U64:
+		return v.Uint64()
+	case MNL_TYPE_STRING:
+		return v.StringValue()
+	case MNL_TYPE_FLAG:
+		return v.Bool()
+	}
+	return nil, fmt.Errorf("unsupported parameter type %d", v.Type)
+}
+
+// DevlinkPortParamList returns all port parameters of a devlink device with
+// the values of every supported configuration mode, otherwise returns an error code.
+// Equivalent to: `devlink port param show`
+func (h *Handle) DevlinkPortParamList(Socket string, Bus string, Device string) ([]*DevlinkDevParamInfo, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_PORT_PARAM_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkDevParamList(msgs)
+}
+
+// DevlinkPortParamList returns all port parameters of a devlink device with
+// the values of every supported configuration mode, otherwise returns an error code.
+// Equivalent to: `devlink port param show`
+func DevlinkPortParamList(Socket string, Bus string, Device string) ([]*DevlinkDevParamInfo, error) {
+	return pkgHandle.DevlinkPortParamList(Socket, Bus, Device)
+}
+
+// DevlinkPortParamGet returns information about a set port parameter
+// Equivalent to `devlink port param show $dev/$port name $param`
+func (h *Handle) DevlinkPortParamGet(Socket string, Bus string, Device string, PortIndex uint32, ParamName string) (*DevlinkDevParam, error) {
+	return h.devParamGet(Socket, Bus, Device, &PortIndex, ParamName)
+}
+
+// DevlinkPortParamGet returns information about a set port parameter
+// Equivalent to `devlink port param show $dev/$port name $param`
+func DevlinkPortParamGet(Socket string, Bus string, Device string, PortIndex uint32, ParamName string) (*DevlinkDevParam, error) {
+	return pkgHandle.DevlinkPortParamGet(Socket, Bus, Device, PortIndex, ParamName)
+}
+
+// DevlinkPortParamSet sets one port parameter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port param set $dev/$port name $param value $value cmode runtime`
+func (h *Handle) DevlinkPortParamSet(Socket string, Bus string, Device string, PortIndex uint32, ParamName string, NewValue string, NewCMode string) error {
+	setParam, err := h.DevlinkPortParamGet(Socket, Bus, Device, PortIndex, ParamName)
+	if err != nil {
+		return err
+	}
+
+	paramType := uint8(setParam.Attribute.Type)
+	value, err := paramStringToValue(paramType, NewValue)
+	if err != nil {
+		return err
+	}
+	return h.devParamSet(Socket, Bus, Device, &PortIndex, ParamName, paramType, value, NewCMode)
+}
+
+// DevlinkPortParamSet sets one port parameter.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port param set $dev/$port name $param value $value cmode runtime`
+func DevlinkPortParamSet(Socket string, Bus string, Device string, PortIndex uint32, ParamName string, NewValue string, NewCMode string) error {
+	return pkgHandle.DevlinkPortParamSet(Socket, Bus, Device, PortIndex, ParamName, NewValue, NewCMode)
+}
+
+// DevlinkPortParamSetTyped sets one port parameter from a Go value, see
+// DevlinkDevParamSetTyped for the accepted value types.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port param set $dev/$port name $param value $value cmode runtime`
+func (h *Handle) DevlinkPortParamSetTyped(Socket string, Bus string, Device string, PortIndex uint32, ParamName string, NewValue any, NewCMode string) error {
+	setParam, err := h.DevlinkPortParamGet(Socket, Bus, Device, PortIndex, ParamName)
+	if err != nil {
+		return err
+	}
+	return h.devParamSet(Socket, Bus, Device, &PortIndex, ParamName, uint8(setParam.Attribute.Type), NewValue, NewCMode)
+}
+
+// DevlinkPortParamSetTyped sets one port parameter from a Go value, see
+// DevlinkDevParamSetTyped for the accepted value types.
+// It returns nil on success or error code.
+// Equivalent to: `devlink port param set $dev/$port name $param value $value cmode runtime`
+func DevlinkPortParamSetTyped(Socket string, Bus string, Device string, PortIndex uint32, ParamName string, NewValue any, NewCMode string) error {
+	return pkgHandle.DevlinkPortParamSetTyped(Socket, Bus, Device, PortIndex, ParamName, NewValue, NewCMode)
+}
+
+func parseLinecardState(state uint8) string {
+	var linecardStates = map[uint8]string{
+		DEVLINK_LINECARD_STATE_UNPROVISIONED:       "unprovisioned",
+		DEVLINK_LINECARD_STATE_UNPROVISIONING:      "unprovisioning",
+		DEVLINK_LINECARD_STATE_PROVISIONING:        "provisioning",
+		DEVLINK_LINECARD_STATE_PROVISIONING_FAILED: "provisioning_failed",
+		DEVLINK_LINECARD_STATE_PROVISIONED:         "provisioned",
+		DEVLINK_LINECARD_STATE_ACTIVE:              "active",
+	}
+	if linecardStates[state] == "" {
+		return "unknown"
+	}
+	return linecardStates[state]
+}
+
+func parseDevlinkNestedDevice(data []byte) (*DevlinkNestedDevice, error) {
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return nil, err
+	}
+	nested := &DevlinkNestedDevice{}
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			nested.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			nested.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_NETNS_ID:
+			nested.NetnsID = int32(native.Uint32(a.Value))
+			nested.NetnsIDValid = true
+		}
+	}
+	return nested, nil
+}
+
+func (lc *DevlinkLinecard) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		var err error
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_BUS_NAME:
+			lc.BusName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DEV_NAME:
+			lc.DeviceName = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_LINECARD_INDEX:
+			lc.Index = native.Uint32(a.Value)
+		case DEVLINK_ATTR_LINECARD_STATE:
+			lc.State = parseLinecardState(uint8(a.Value[0]))
+		case DEVLINK_ATTR_LINECARD_TYPE:
+			lc.Type = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_LINECARD_SUPPORTED_TYPES:
+			var types []syscall.NetlinkRouteAttr
+			types, err = nl.ParseRouteAttr(a.Value)
+			for _, t := range types {
+				if t.Attr.Type&nl.NLA_TYPE_MASK == DEVLINK_ATTR_LINECARD_TYPE {
+					lc.SupportedTypes = append(lc.SupportedTypes, nl.BytesToString(t.Value))
+				}
+			}
+		case DEVLINK_ATTR_NESTED_DEVLINK:
+			lc.Nested, err = parseDevlinkNestedDevice(a.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseDevlinkLinecardList(msgs [][]byte) ([]*DevlinkLinecard, error) {
+	linecards := make([]*DevlinkLinecard, 0, len(msgs))
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		lc := &DevlinkLinecard{}
+		if err = lc.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		linecards = append(linecards, lc)
+	}
+	return linecards, nil
+}
+
+// DevlinkLinecardList returns all line cards of a devlink device,
+// otherwise returns an error code.
+// Equivalent to: `devlink lc show $dev`
+func (h *Handle) DevlinkLinecardList(Socket string, Bus string, Device string) ([]*DevlinkLinecard, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_LINECARD_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkLinecardList(msgs)
+}
+
+// DevlinkLinecardList returns all line cards of a devlink device,
+// otherwise returns an error code.
+// Equivalent to: `devlink lc show $dev`
+func DevlinkLinecardList(Socket string, Bus string, Device string) ([]*DevlinkLinecard, error) {
+	return pkgHandle.DevlinkLinecardList(Socket, Bus, Device)
+}
+
+// DevlinkLinecardGet returns a line card, otherwise returns an error code.
+// Equivalent to: `devlink lc show $dev lc 8`
+func (h *Handle) DevlinkLinecardGet(Socket string, Bus string, Device string, Index uint32) (*DevlinkLinecard, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_LINECARD_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_LINECARD_INDEX, nl.Uint32Attr(Index)))
+
+	respmsg, err := executeGet(req)
+	if err != nil {
+		return nil, err
+	}
+	linecards, err := parseDevlinkLinecardList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	return linecards[0], nil
+}
+
+// DevlinkLinecardGet returns a line card, otherwise returns an error code.
+// Equivalent to: `devlink lc show $dev lc 8`
+func DevlinkLinecardGet(Socket string, Bus string, Device string, Index uint32) (*DevlinkLinecard, error) {
+	return pkgHandle.DevlinkLinecardGet(Socket, Bus, Device, Index)
+}
+
+// DevlinkLinecardProvision provisions a line card slot with one of its
+// supported types. Provisioning completes asynchronously, the line card
+// state moves from provisioning to provisioned or provisioning_failed.
+// It returns nil on success or error code.
+// Equivalent to: `devlink lc set $dev lc 8 type 16x100G`
+func (h *Handle) DevlinkLinecardProvision(Socket string, Bus string, Device string, Index uint32, Type string) error {
+	if Type == "" {
+		return fmt.Errorf("line card type must not be empty")
+	}
+	return h.linecardSetType(Socket, Bus, Device, Index, Type)
+}
+
+// DevlinkLinecardProvision provisions a line card slot with one of its
+// supported types. Provisioning completes asynchronously, the line card
+// state moves from provisioning to provisioned or provisioning_failed.
+// It returns nil on success or error code.
+// Equivalent to: `devlink lc set $dev lc 8 type 16x100G`
+func DevlinkLinecardProvision(Socket string, Bus string, Device string, Index uint32, Type string) error {
+	return pkgHandle.DevlinkLinecardProvision(Socket, Bus, Device, Index, Type)
+}
+
+// DevlinkLinecardUnprovision unprovisions a line card slot.
+// It returns nil on success or error code.
+// Equivalent to: `devlink lc set $dev lc 8 notype`
+func (h *Handle) DevlinkLinecardUnprovision(Socket string, Bus string, Device string, Index uint32) error {
+	// an empty type unprovisions the line card
+	return h.linecardSetType(Socket, Bus, Device, Index, "")
+}
+
+// DevlinkLinecardUnprovision unprovisions a line card slot.
+// It returns nil on success or error code.
+// Equivalent to: `devlink lc set $dev lc 8 notype`
+func DevlinkLinecardUnprovision(Socket string, Bus string, Device string, Index uint32) error {
+	return pkgHandle.DevlinkLinecardUnprovision(Socket, Bus, Device, Index)
+}
+
+func (h *Handle) linecardSetType(Socket string, Bus string, Device string, Index uint32, Type string) error {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_LINECARD_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_LINECARD_INDEX, nl.Uint32Attr(Index)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_LINECARD_TYPE, nl.ZeroTerminated(Type)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+var selftestNames = map[uint16]string{
+	DEVLINK_ATTR_SELFTEST_ID_FLASH: "flash",
+}
+
+func selftestStringToID(name string) (uint16, error) {
+	for id, n := range selftestNames {
+		if n == name {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid selftest %s", name)
+}
+
+func parseSelftestName(id uint16) string {
+	if name, ok := selftestNames[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", id)
+}
+
+func parseSelftestStatus(status uint8) string {
+	var selftestStatus = map[uint8]string{
+		DEVLINK_SELFTEST_STATUS_SKIP: "skip",
+		DEVLINK_SELFTEST_STATUS_PASS: "pass",
+		DEVLINK_SELFTEST_STATUS_FAIL: "fail",
+	}
+	if selftestStatus[status] == "" {
+		return "unknown"
+	}
+	return selftestStatus[status]
+}
+
+// selftestsAttr returns the nested DEVLINK_ATTR_SELFTESTS of a response message
+func selftestsAttr(msgs [][]byte) ([]syscall.NetlinkRouteAttr, error) {
+	if len(msgs) != 1 {
+		return nil, fmt.Errorf("expected only one nl response msg")
+	}
+	attrs, err := nl.ParseRouteAttr(msgs[0][nl.SizeofGenlmsg:])
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range attrs {
+		if a.Attr.Type&nl.NLA_TYPE_MASK == DEVLINK_ATTR_SELFTESTS {
+			return nl.ParseRouteAttr(a.Value)
+		}
+	}
+	return nil, nil
+}
+
+func parseDevlinkSelftestIDs(msgs [][]byte) ([]uint16, error) {
+	attrs, err := selftestsAttr(msgs)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint16, 0, len(attrs))
+	for _, a := range attrs {
+		ids = append(ids, a.Attr.Type&nl.NLA_TYPE_MASK)
+	}
+	return ids, nil
+}
+
+func parseDevlinkSelftests(msgs [][]byte) ([]string, error) {
+	ids, err := parseDevlinkSelftestIDs(msgs)
+	if err != nil {
+		return nil, err
+	}
+	selftests := make([]string, 0, len(ids))
+	for _, id := range ids {
+		selftests = append(selftests, parseSelftestName(id))
+	}
+	return selftests, nil
+}
+
+func parseDevlinkSelftestResults(msgs [][]byte) (map[string]string, error) {
+	attrs, err := selftestsAttr(msgs)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		if a.Attr.Type&nl.NLA_TYPE_MASK != DEVLINK_ATTR_SELFTEST_RESULT {
+			continue
+		}
+		resultAttrs, err := nl.ParseRouteAttr(a.Value)
+		if err != nil {
+			return nil, err
+		}
+		var name, status string
+		for _, r := range resultAttrs {
+			switch r.Attr.Type & nl.NLA_TYPE_MASK {
+			case DEVLINK_ATTR_SELFTEST_RESULT_ID:
+				name = parseSelftestName(uint16(native.Uint32(r.Value)))
+			case DEVLINK_ATTR_SELFTEST_RESULT_STATUS:
+				status = parseSelftestStatus(uint8(r.Value[0]))
+			}
+		}
+		results[name] = status
+	}
+	return results, nil
+}
+
+func (h *Handle) devlinkSelftestsGet(Socket string, Bus string, Device string) ([][]byte, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SELFTESTS_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return req.Execute(unix.NETLINK_GENERIC, 0)
+}
+
+// DevlinkSelftestsGet returns the names of the selftests supported by a
+// devlink device, otherwise returns an error code.
+// Equivalent to: `devlink dev selftests show $dev`
+func (h *Handle) DevlinkSelftestsGet(Socket string, Bus string, Device string) ([]string, error) {
+	respmsg, err := h.devlinkSelftestsGet(Socket, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkSelftests(respmsg)
+}
+
+// DevlinkSelftestsGet returns the names of the selftests supported by a
+// devlink device, otherwise returns an error code.
+// Equivalent to: `devlink dev selftests show $dev`
+func DevlinkSelftestsGet(Socket string, Bus string, Device string) ([]string, error) {
+	return pkgHandle.DevlinkSelftestsGet(Socket, Bus, Device)
+}
+
+// DevlinkSelftestsRun runs the named selftests, or all the supported selftests
+// when Selftests is empty. It returns the pass, fail or skip result of each
+// selftest by name, otherwise returns an error code.
+// Equivalent to: `devlink dev selftests run $dev id flash`
+func (h *Handle) DevlinkSelftestsRun(Socket string, Bus string, Device string, Selftests []string) (map[string]string, error) {
+	var ids []uint16
+	if len(Selftests) == 0 {
+		// run by the IDs the device reports, so tests without a known
+		// name are still requested
+		respmsg, err := h.devlinkSelftestsGet(Socket, Bus, Device)
+		if err != nil {
+			return nil, err
+		}
+		ids, err = parseDevlinkSelftestIDs(respmsg)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("device %s/%s does not support selftests", Bus, Device)
+		}
+	}
+	for _, name := range Selftests {
+		id, err := selftestStringToID(name)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	testsAttr := nl.NewRtAttr(DEVLINK_ATTR_SELFTESTS|unix.NLA_F_NESTED, nil)
+	for _, id := range ids {
+		testsAttr.AddRtAttr(int(id), nil)
+	}
+
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_SELFTESTS_RUN, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	req.AddData(testsAttr)
+
+	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkSelftestResults(respmsg)
+}
+
+// DevlinkSelftestsRun runs the named selftests, or all the supported selftests
+// when Selftests is empty. It returns the pass, fail or skip result of each
+// selftest by name, otherwise returns an error code.
+// Equivalent to: `devlink dev selftests run $dev id flash`
+func DevlinkSelftestsRun(Socket string, Bus string, Device string, Selftests []string) (map[string]string, error) {
+	return pkgHandle.DevlinkSelftestsRun(Socket, Bus, Device, Selftests)
+}
+
+// parseDpipeNested returns the values of the attributes of type attrType
+// nested in the attribute of type nestType of all response messages
+func parseDpipeNested(msgs [][]byte, nestType uint16, attrType uint16) ([][]byte, error) {
+	var values [][]byte
+	for _, m := range msgs {
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range attrs {
+			if a.Attr.Type&nl.NLA_TYPE_MASK != nestType {
+				continue
+			}
+			nested, err := nl.ParseRouteAttr(a.Value)
+			if err != nil {
+				return nil, err
+			}
+			for _, n := range nested {
+				if n.Attr.Type&nl.NLA_TYPE_MASK == attrType {
+					values = append(values, n.Value)
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+func parseDpipeFieldRef(data []byte, typeAttr uint16) (DevlinkDpipeFieldRef, error) {
+	ref := DevlinkDpipeFieldRef{}
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return ref, err
+	}
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case typeAttr:
+			ref.Type = native.Uint32(a.Value)
+		case DEVLINK_ATTR_DPIPE_HEADER_ID:
+			ref.HeaderID = native.Uint32(a.Value)
+		case DEVLINK_ATTR_DPIPE_HEADER_GLOBAL:
+			ref.HeaderGlobal = uint8(a.Value[0]) != 0
+		case DEVLINK_ATTR_DPIPE_HEADER_INDEX:
+			ref.HeaderIndex = native.Uint32(a.Value)
+		case DEVLINK_ATTR_DPIPE_FIELD_ID:
+			ref.FieldID = native.Uint32(a.Value)
+		}
+	}
+	return ref, nil
+}
+
+// parseDpipeFieldRefList parses a list of DEVLINK_ATTR_DPIPE_MATCH or
+// DEVLINK_ATTR_DPIPE_ACTION attributes
+func parseDpipeFieldRefList(data []byte, refAttr uint16, typeAttr uint16) ([]DevlinkDpipeFieldRef, error) {
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]DevlinkDpipeFieldRef, 0, len(attrs))
+	for _, a := range attrs {
+		if a.Attr.Type&nl.NLA_TYPE_MASK != refAttr {
+			continue
+		}
+		ref, err := parseDpipeFieldRef(a.Value, typeAttr)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// parseDpipeValueList parses a list of DEVLINK_ATTR_DPIPE_MATCH_VALUE or
+// DEVLINK_ATTR_DPIPE_ACTION_VALUE attributes
+func parseDpipeValueList(data []byte, valueAttr uint16, refAttr uint16, typeAttr uint16) ([]DevlinkDpipeValue, error) {
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]DevlinkDpipeValue, 0, len(attrs))
+	for _, a := range attrs {
+		if a.Attr.Type&nl.NLA_TYPE_MASK != valueAttr {
+			continue
+		}
+		valueAttrs, err := nl.ParseRouteAttr(a.Value)
+		if err != nil {
+			return nil, err
+		}
+		value := DevlinkDpipeValue{}
+		for _, v := range valueAttrs {
+			switch v.Attr.Type & nl.NLA_TYPE_MASK {
+			case refAttr:
+				value.Field, err = parseDpipeFieldRef(v.Value, typeAttr)
+				if err != nil {
+					return nil, err
+				}
+			case DEVLINK_ATTR_DPIPE_VALUE:
+				value.Value = v.Value
+			case DEVLINK_ATTR_DPIPE_VALUE_MASK:
+				value.Mask = v.Value
+			case DEVLINK_ATTR_DPIPE_VALUE_MAPPING:
+				value.Mapping = native.Uint32(v.Value)
+				value.MappingValid = true
+			}
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (table *DevlinkDpipeTable) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		var err error
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_DPIPE_TABLE_NAME:
+			table.Name = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DPIPE_TABLE_SIZE:
+			table.Size = native.Uint64(a.Value)
+		case DEVLINK_ATTR_DPIPE_TABLE_COUNTERS_ENABLED:
+			table.CountersEnabled = uint8(a.Value[0]) != 0
+		case DEVLINK_ATTR_DPIPE_TABLE_RESOURCE_ID:
+			table.ResourceID = native.Uint64(a.Value)
+			table.ResourceValid = true
+		case DEVLINK_ATTR_DPIPE_TABLE_RESOURCE_UNITS:
+			table.ResourceUnits = native.Uint64(a.Value)
+		case DEVLINK_ATTR_DPIPE_TABLE_MATCHES:
+			table.Matches, err = parseDpipeFieldRefList(a.Value, DEVLINK_ATTR_DPIPE_MATCH, DEVLINK_ATTR_DPIPE_MATCH_TYPE)
+		case DEVLINK_ATTR_DPIPE_TABLE_ACTIONS:
+			table.Actions, err = parseDpipeFieldRefList(a.Value, DEVLINK_ATTR_DPIPE_ACTION, DEVLINK_ATTR_DPIPE_ACTION_TYPE)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (entry *DevlinkDpipeEntry) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		var err error
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_DPIPE_ENTRY_INDEX:
+			entry.Index = native.Uint64(a.Value)
+		case DEVLINK_ATTR_DPIPE_ENTRY_MATCH_VALUES:
+			entry.MatchValues, err = parseDpipeValueList(a.Value, DEVLINK_ATTR_DPIPE_MATCH_VALUE,
+				DEVLINK_ATTR_DPIPE_MATCH, DEVLINK_ATTR_DPIPE_MATCH_TYPE)
+		case DEVLINK_ATTR_DPIPE_ENTRY_ACTION_VALUES:
+			entry.ActionValues, err = parseDpipeValueList(a.Value, DEVLINK_ATTR_DPIPE_ACTION_VALUE,
+				DEVLINK_ATTR_DPIPE_ACTION, DEVLINK_ATTR_DPIPE_ACTION_TYPE)
+		case DEVLINK_ATTR_DPIPE_ENTRY_COUNTER:
+			entry.Counter = native.Uint64(a.Value)
+			entry.CounterValid = true
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (header *DevlinkDpipeHeader) parseAttributes(attrs []syscall.NetlinkRouteAttr) error {
+	for _, a := range attrs {
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case DEVLINK_ATTR_DPIPE_HEADER_NAME:
+			header.Name = nl.BytesToString(a.Value)
+		case DEVLINK_ATTR_DPIPE_HEADER_ID:
+			header.ID = native.Uint32(a.Value)
+		case DEVLINK_ATTR_DPIPE_HEADER_GLOBAL:
+			header.Global = uint8(a.Value[0]) != 0
+		case DEVLINK_ATTR_DPIPE_HEADER_FIELDS:
+			fields, err := nl.ParseRouteAttr(a.Value)
+			if err != nil {
+				return err
+			}
+			for _, f := range fields {
+				if f.Attr.Type&nl.NLA_TYPE_MASK != DEVLINK_ATTR_DPIPE_FIELD {
+					continue
+				}
+				fieldAttrs, err := nl.ParseRouteAttr(f.Value)
+				if err != nil {
+					return err
+				}
+				field := DevlinkDpipeField{}
+				for _, fa := range fieldAttrs {
+					switch fa.Attr.Type & nl.NLA_TYPE_MASK {
+					case DEVLINK_ATTR_DPIPE_FIELD_NAME:
+						field.Name = nl.BytesToString(fa.Value)
+					case DEVLINK_ATTR_DPIPE_FIELD_ID:
+						field.ID = native.Uint32(fa.Value)
+					case DEVLINK_ATTR_DPIPE_FIELD_BITWIDTH:
+						field.Bitwidth = native.Uint32(fa.Value)
+					case DEVLINK_ATTR_DPIPE_FIELD_MAPPING_TYPE:
+						field.MappingType = native.Uint32(fa.Value)
+					}
+				}
+				header.Fields = append(header.Fields, field)
+			}
+		}
+	}
+	return nil
+}
+
+func parseDevlinkDpipeTableList(msgs [][]byte) ([]*DevlinkDpipeTable, error) {
+	nested, err := parseDpipeNested(msgs, DEVLINK_ATTR_DPIPE_TABLES, DEVLINK_ATTR_DPIPE_TABLE)
+	if err != nil {
+		return nil, err
+	}
+	tables := make([]*DevlinkDpipeTable, 0, len(nested))
+	for _, data := range nested {
+		attrs, err := nl.ParseRouteAttr(data)
+		if err != nil {
+			return nil, err
+		}
+		table := &DevlinkDpipeTable{}
+		if err = table.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+func parseDevlinkDpipeEntryList(msgs [][]byte) ([]*DevlinkDpipeEntry, error) {
+	nested, err := parseDpipeNested(msgs, DEVLINK_ATTR_DPIPE_ENTRIES, DEVLINK_ATTR_DPIPE_ENTRY)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*DevlinkDpipeEntry, 0, len(nested))
+	for _, data := range nested {
+		attrs, err := nl.ParseRouteAttr(data)
+		if err != nil {
+			return nil, err
+		}
+		entry := &DevlinkDpipeEntry{}
+		if err = entry.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseDevlinkDpipeHeaderList(msgs [][]byte) ([]*DevlinkDpipeHeader, error) {
+	nested, err := parseDpipeNested(msgs, DEVLINK_ATTR_DPIPE_HEADERS, DEVLINK_ATTR_DPIPE_HEADER)
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]*DevlinkDpipeHeader, 0, len(nested))
+	for _, data := range nested {
+		attrs, err := nl.ParseRouteAttr(data)
+		if err != nil {
+			return nil, err
+		}
+		header := &DevlinkDpipeHeader{}
+		if err = header.parseAttributes(attrs); err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// DevlinkDpipeTableList returns all dpipe tables of a devlink device,
+// otherwise returns an error code.
+// Equivalent to: `devlink dpipe table show $dev`
+func (h *Handle) DevlinkDpipeTableList(Socket string, Bus string, Device string) ([]*DevlinkDpipeTable, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_DPIPE_TABLE_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkDpipeTableList(respmsg)
+}
+
+// DevlinkDpipeTableList returns all dpipe tables of a devlink device,
+// otherwise returns an error code.
+// Equivalent to: `devlink dpipe table show $dev`
+func DevlinkDpipeTableList(Socket string, Bus string, Device string) ([]*DevlinkDpipeTable, error) {
+	return pkgHandle.DevlinkDpipeTableList(Socket, Bus, Device)
+}
+
+// DevlinkDpipeTableGet returns a dpipe table, otherwise returns an error code.
+// Equivalent to: `devlink dpipe table show $dev name mlxsw_erif`
+func (h *Handle) DevlinkDpipeTableGet(Socket string, Bus string, Device string, Name string) (*DevlinkDpipeTable, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_DPIPE_TABLE_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_DPIPE_TABLE_NAME, nl.ZeroTerminated(Name)))
+
+	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := parseDevlinkDpipeTableList(respmsg)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) != 1 {
+		return nil, fmt.Errorf("expected only one dpipe table")
+	}
+	return tables[0], nil
+}
+
+// DevlinkDpipeTableGet returns a dpipe table, otherwise returns an error code.
+// Equivalent to: `devlink dpipe table show $dev name mlxsw_erif`
+func DevlinkDpipeTableGet(Socket string, Bus string, Device string, Name string) (*DevlinkDpipeTable, error) {
+	return pkgHandle.DevlinkDpipeTableGet(Socket, Bus, Device, Name)
+}
+
+// DevlinkDpipeEntries returns the entries of a dpipe table with their match
+// and action values, otherwise returns an error code.
+// Equivalent to: `devlink dpipe table dump $dev name mlxsw_erif`
+func (h *Handle) DevlinkDpipeEntries(Socket string, Bus string, Device string, TableName string) ([]*DevlinkDpipeEntry, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_DPIPE_ENTRIES_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_DPIPE_TABLE_NAME, nl.ZeroTerminated(TableName)))
+
+	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkDpipeEntryList(respmsg)
+}
+
+// DevlinkDpipeEntries returns the entries of a dpipe table with their match
+// and action values, otherwise returns an error code.
+// Equivalent to: `devlink dpipe table dump $dev name mlxsw_erif`
+func DevlinkDpipeEntries(Socket string, Bus string, Device string, TableName string) ([]*DevlinkDpipeEntry, error) {
+	return pkgHandle.DevlinkDpipeEntries(Socket, Bus, Device, TableName)
+}
+
+// DevlinkDpipeHeaders returns the dpipe headers of a devlink device with
+// their fields, otherwise returns an error code.
+// Equivalent to: `devlink dpipe header show $dev`
+func (h *Handle) DevlinkDpipeHeaders(Socket string, Bus string, Device string) ([]*DevlinkDpipeHeader, error) {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_DPIPE_HEADERS_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+
+	respmsg, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevlinkDpipeHeaderList(respmsg)
+}
+
+// DevlinkDpipeHeaders returns the dpipe headers of a devlink device with
+// their fields, otherwise returns an error code.
+// Equivalent to: `devlink dpipe header show $dev`
+func DevlinkDpipeHeaders(Socket string, Bus string, Device string) ([]*DevlinkDpipeHeader, error) {
+	return pkgHandle.DevlinkDpipeHeaders(Socket, Bus, Device)
+}
+
+// DevlinkDpipeTableCountersSet enables or disables the entry counters of a dpipe table.
+// It returns nil on success or error code.
+// Equivalent to: `devlink dpipe table set $dev name mlxsw_erif counters enable`
+func (h *Handle) DevlinkDpipeTableCountersSet(Socket string, Bus string, Device string, TableName string, Enable bool) error {
+	_, req, err := h.createCmdReq(Socket, DEVLINK_CMD_DPIPE_TABLE_COUNTERS_SET, Bus, Device)
+	if err != nil {
+		return err
+	}
+
+	enable := uint8(0)
+	if Enable {
+		enable = 1
+	}
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_DPIPE_TABLE_NAME, nl.ZeroTerminated(TableName)))
+	req.AddData(nl.NewRtAttr(DEVLINK_ATTR_DPIPE_TABLE_COUNTERS_ENABLED, nl.Uint8Attr(enable)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// DevlinkDpipeTableCountersSet enables or disables the entry counters of a dpipe table.
+// It returns nil on success or error code.
+// Equivalent to: `devlink dpipe table set $dev name mlxsw_erif counters enable`
+func DevlinkDpipeTableCountersSet(Socket string, Bus string, Device string, TableName string, Enable bool) error {
+	return pkgHandle.DevlinkDpipeTableCountersSet(Socket, Bus, Device, TableName, Enable)
+}
+
+// DevlinkGetPortFnDevice returns the devlink device of the auxiliary devlink
+// instance spawned by the function of an active SF port, otherwise returns an
+// error code. The port must be obtained through netlink family 'devlink'.
+// Equivalent to: `devlink port show $port` followed by `devlink dev show auxiliary/mlx5_core.sf.2`
+func (h *Handle) DevlinkGetPortFnDevice(Socket string, Port *DevlinkPort) (*DevlinkDevice, error) {
+	if Port.Fn == nil || Port.Fn.Devlink == nil {
+		return nil, fmt.Errorf("port %d has no nested devlink instance", Port.PortIndex)
+	}
+	if Port.Fn.Devlink.NetnsIDValid {
+		return nil, fmt.Errorf("nested devlink instance %s/%s is in netns %d",
+			Port.Fn.Devlink.BusName, Port.Fn.Devlink.DeviceName, Port.Fn.Devlink.NetnsID)
+	}
+	return h.DevlinkGetDeviceByName(Socket, Port.Fn.Devlink.BusName, Port.Fn.Devlink.DeviceName)
+}
+
+// DevlinkGetPortFnDevice returns the devlink device of the auxiliary devlink
+// instance spawned by the function of an active SF port, otherwise returns an
+// error code. The port must be obtained through netlink family 'devlink'.
+// Equivalent to: `devlink port show $port` followed by `devlink dev show auxiliary/mlx5_core.sf.2`
+func DevlinkGetPortFnDevice(Socket string, Port *DevlinkPort) (*DevlinkDevice, error) {
+	return pkgHandle.DevlinkGetPortFnDevice(Socket, Port)
+}
+
+func (filter *DevlinkPortFilter) match(port *DevlinkPort) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.FlavourValid && port.PortFlavour != filter.Flavour {
+		return false
+	}
+	if filter.PfNumberValid && (!port.PfNumberValid || port.PfNumber != filter.PfNumber) {
+		return false
+	}
+	if filter.SfNumberValid && (!port.SfNumberValid || port.SfNumber != filter.SfNumber) {
+		return false
+	}
+	if filter.ControllerValid && (!port.ControllerValid || port.Controller != filter.Controller) {
+		return false
+	}
+	return true
+}
+
+// DevlinkGetPortList provides the ports of a devlink device matching the
+// filter and nil error, otherwise returns an error code. A nil filter returns
+// all ports of the device.
+// Equivalent to: `devlink port show $dev`
+func (h *Handle) DevlinkGetPortList(Socket string, Bus string, Device string, Filter *DevlinkPortFilter) ([]*DevlinkPort, error) {
+	msgs, err := h.executeDeviceDump(Socket, DEVLINK_CMD_PORT_GET, Bus, Device)
+	if err != nil {
+		return nil, err
+	}
+	ports, err := parseDevlinkAllPortList(Socket, msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*DevlinkPort, 0, len(ports))
+	for _, port := range ports {
+		if Filter.match(port) {
+			filtered = append(filtered, port)
+		}
+	}
+	return filtered, nil
+}
+
+// DevlinkGetPortList provides the ports of a devlink device matching the
+// filter and nil error, otherwise returns an error code. A nil filter returns
+// all ports of the device.
+// Equivalent to: `devlink port show $dev`
+func DevlinkGetPortList(Socket string, Bus string, Device string, Filter *DevlinkPortFilter) ([]*DevlinkPort, error) {
+	return pkgHandle.DevlinkGetPortList(Socket, Bus, Device, Filter)
+}
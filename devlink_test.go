@@ -4,6 +4,8 @@
 package mlxdevm
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"flag"
 	"net"
@@ -11,6 +13,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
 )
 
 func validateArgs(t *testing.T) error {
@@ -47,6 +52,72 @@ func TestDevlinkGetDeviceByName(t *testing.T) {
 	}
 }
 
+func TestDevlinkGetDeviceInfo(t *testing.T) {
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := DevlinkGetDeviceInfo(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.BusName != bus || info.DeviceName != device {
+		t.Fatalf("missmatching bus/device")
+	}
+	t.Logf("Device Info: %+v", *info)
+
+	infos, err := DevlinkGetDeviceInfoList(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("devlink device info count = ", len(infos))
+}
+
+func TestEswitchModeStringRoundTrip(t *testing.T) {
+	for _, name := range []string{"none", "link", "network", "transport"} {
+		mode, err := eswitchStringToInlineMode(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, name, parseEswitchInlineMode(mode))
+	}
+	for _, name := range []string{"disable", "enable"} {
+		mode, err := eswitchStringToEncapMode(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, name, parseEswitchEncapMode(mode))
+	}
+	_, err := eswitchStringToInlineMode("unknown")
+	assert.Error(t, err)
+	_, err = eswitchStringToEncapMode("basic")
+	assert.Error(t, err)
+}
+
+func TestDevlinkSetEswitch(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkSetEswitch in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev, err := DevlinkGetDeviceByName(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = DevlinkSetEswitch(socket, dev, DevlinkDevEswitchAttr{})
+	assert.Error(t, err)
+
+	// re-apply the current attributes to exercise all of them in one request
+	err = DevlinkSetEswitch(socket, dev, dev.Attrs.Eswitch)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestDevlinkGetAllPortList(t *testing.T) {
 	ports, err := DevlinkGetAllPortList(socket)
 	if err != nil {
@@ -204,6 +275,16 @@ func TestDevlinkPortFnCapSet(t *testing.T) {
 			},
 			errExpected: false,
 		},
+		{
+			name: "Roce true, max_uc_macs 512, verified",
+			fnCapAttrs: DevlinkPortFnCapSetAttrs{
+				RoceValid:   true,
+				FnCapAttrs:  DevlinkPortFnCap{Roce: true, UCList: 512},
+				UCListValid: true,
+				Verify:      true,
+			},
+			errExpected: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -294,11 +375,1276 @@ func TestDevlinkGetDeviceResources(t *testing.T) {
 	t.Logf("Resources: %+v", res)
 }
 
+func TestDevlinkDevReload(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkDevReload in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := DevlinkDevReloadAttrs{
+		Action:      DEVLINK_RELOAD_ACTION_DRIVER_REINIT,
+		ActionValid: true,
+	}
+	performed, err := DevlinkDevReload(socket, bus, device, attrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if performed&(1<<DEVLINK_RELOAD_ACTION_DRIVER_REINIT) == 0 {
+		t.Fatalf("driver_reinit not reported as performed, got %#x", performed)
+	}
+
+	dev, err := DevlinkGetDeviceByName(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Reload Stats: %+v", dev.Attrs.ReloadStats)
+}
+
+func TestDevlinkDevReloadInvalidLimit(t *testing.T) {
+	for _, limit := range []uint8{DEVLINK_RELOAD_LIMIT_UNSPEC, DEVLINK_RELOAD_LIMIT_MAX + 1} {
+		attrs := DevlinkDevReloadAttrs{
+			Limit:      limit,
+			LimitValid: true,
+		}
+		_, err := DevlinkDevReload(GENL_DEVLINK_NAME, "pci", "0000:00:00.0", attrs)
+		assert.Error(t, err, "limit %d must be rejected", limit)
+	}
+}
+
+func TestDevlinkDevFlash(t *testing.T) {
+	if flashfile == "" {
+		t.Skip("Skipping test TestDevlinkDevFlash, no flash file specified")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var statuses []DevlinkFlashStatus
+	err = DevlinkDevFlash(socket, bus, device, DevlinkFlashUpdateAttrs{FileName: flashfile},
+		func(st DevlinkFlashStatus) {
+			statuses = append(statuses, st)
+			t.Logf("Flash Status: %+v", st)
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("flash status notification count = ", len(statuses))
+}
+
+func TestHandleZeroValueNetns(t *testing.T) {
+	h := &Handle{}
+	assert.Equal(t, netns.None(), h.nsHandle())
+
+	// must not close fd 0 of a handle without a network namespace
+	_, before := unix.FcntlInt(0, unix.F_GETFD, 0)
+	h.Delete()
+	_, after := unix.FcntlInt(0, unix.F_GETFD, 0)
+	assert.Equal(t, before, after)
+}
+
+func TestDevlinkHealthReporters(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkHealthReporters in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reporters, err := DevlinkHealthReporterList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("devlink health reporter count = ", len(reporters))
+	for _, r := range reporters {
+		t.Logf("Health Reporter: %+v", *r)
+
+		reporterAttrs := DevlinkHealthReporterAttrs{
+			Name:           r.Name,
+			PortIndex:      r.PortIndex,
+			PortIndexValid: r.PortIndexValid,
+		}
+		reporter, err := DevlinkHealthReporterGet(socket, bus, device, reporterAttrs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, r.Name, reporter.Name, "miss-matching reporter name")
+
+		if r.GracePeriod != 0 {
+			setAttrs := DevlinkHealthReporterSetAttrs{
+				GracePeriod:      r.GracePeriod,
+				GracePeriodValid: true,
+			}
+			err = DevlinkHealthReporterSet(socket, bus, device, reporterAttrs, setAttrs)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func fmsgAttr(attrType int, data []byte) []byte {
+	return nl.NewRtAttr(attrType, data).Serialize()
+}
+
+func fmsgValuePair(name string, valueType uint8, data []byte) []byte {
+	b := fmsgAttr(DEVLINK_ATTR_FMSG_PAIR_NEST_START, nil)
+	b = append(b, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_NAME, nl.ZeroTerminated(name))...)
+	b = append(b, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_VALUE_TYPE, nl.Uint8Attr(valueType))...)
+	b = append(b, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_VALUE_DATA, data)...)
+	return append(b, fmsgAttr(DEVLINK_ATTR_FMSG_NEST_END, nil)...)
+}
+
+func TestParseDevlinkFmsg(t *testing.T) {
+	var first, second []byte
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_NEST_START, nil)...)
+
+	// "Status": {"Error count": 5, "Healthy": true, "Stale": false}
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_PAIR_NEST_START, nil)...)
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_NAME, nl.ZeroTerminated("Status"))...)
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_NEST_START, nil)...)
+	first = append(first, fmsgValuePair("Error count", MNL_TYPE_U32, nl.Uint32Attr(5))...)
+	first = append(first, fmsgValuePair("Healthy", MNL_TYPE_FLAG, nl.Uint8Attr(1))...)
+	first = append(first, fmsgValuePair("Stale", MNL_TYPE_FLAG, nl.Uint8Attr(0))...)
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_NEST_END, nil)...)
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_NEST_END, nil)...)
+
+	// "SQs": [{"sqn": 1}, {"sqn": 2}], split across two messages
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_PAIR_NEST_START, nil)...)
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_NAME, nl.ZeroTerminated("SQs"))...)
+	first = append(first, fmsgAttr(DEVLINK_ATTR_FMSG_ARR_NEST_START, nil)...)
+	for _, sqn := range []uint64{1, 2} {
+		second = append(second, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_NEST_START, nil)...)
+		second = append(second, fmsgValuePair("sqn", MNL_TYPE_U64, nl.Uint64Attr(sqn))...)
+		second = append(second, fmsgAttr(DEVLINK_ATTR_FMSG_NEST_END, nil)...)
+	}
+	second = append(second, fmsgAttr(DEVLINK_ATTR_FMSG_NEST_END, nil)...)
+	second = append(second, fmsgAttr(DEVLINK_ATTR_FMSG_NEST_END, nil)...)
+
+	second = append(second, fmsgValuePair("desc", MNL_TYPE_NUL_STRING, nl.ZeroTerminated("hello"))...)
+	second = append(second, fmsgAttr(DEVLINK_ATTR_FMSG_NEST_END, nil)...)
+
+	var msgs [][]byte
+	for _, part := range [][]byte{first, second} {
+		msg := make([]byte, nl.SizeofGenlmsg)
+		msg = append(msg, fmsgAttr(DEVLINK_ATTR_FMSG, part)...)
+		msgs = append(msgs, msg)
+	}
+
+	obj, err := parseDevlinkFmsgMsgs(msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `{"Status":{"Error count":5,"Healthy":true,"Stale":false},"SQs":[{"sqn":1},{"sqn":2}],"desc":"hello"}`, string(b))
+
+	m := obj.Map()
+	assert.Equal(t, uint32(5), m["Status"].(map[string]any)["Error count"])
+	assert.Equal(t, true, m["Status"].(map[string]any)["Healthy"])
+	assert.Equal(t, false, m["Status"].(map[string]any)["Stale"])
+	assert.Equal(t, uint64(2), m["SQs"].([]any)[1].(map[string]any)["sqn"])
+
+	desc, ok := obj.Get("desc")
+	assert.True(t, ok)
+	assert.Equal(t, "hello", desc)
+
+	_, err = parseDevlinkFmsgMsgs(msgs[:1])
+	assert.Error(t, err, "truncated formatted message must fail")
+
+	for _, valueType := range []uint8{MNL_TYPE_U8, MNL_TYPE_U16, MNL_TYPE_U32, MNL_TYPE_U64, MNL_TYPE_FLAG} {
+		var short []byte
+		short = append(short, fmsgAttr(DEVLINK_ATTR_FMSG_OBJ_NEST_START, nil)...)
+		short = append(short, fmsgValuePair("short", valueType, []byte{})...)
+		short = append(short, fmsgAttr(DEVLINK_ATTR_FMSG_NEST_END, nil)...)
+		msg := make([]byte, nl.SizeofGenlmsg)
+		msg = append(msg, fmsgAttr(DEVLINK_ATTR_FMSG, short)...)
+		_, err = parseDevlinkFmsgMsgs([][]byte{msg})
+		assert.ErrorContains(t, err, "too short", "empty value data of type %d must fail", valueType)
+	}
+}
+
+func TestDevlinkHealthReporterDiagnose(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkHealthReporterDiagnose in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reporters, err := DevlinkHealthReporterList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range reporters {
+		reporterAttrs := DevlinkHealthReporterAttrs{
+			Name:           r.Name,
+			PortIndex:      r.PortIndex,
+			PortIndexValid: r.PortIndexValid,
+		}
+		diag, err := DevlinkHealthReporterDiagnose(socket, bus, device, reporterAttrs)
+		if err != nil {
+			t.Logf("reporter %s diagnose err = %v", r.Name, err)
+			continue
+		}
+		b, err := json.Marshal(diag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("Health Reporter %s Diagnose: %s", r.Name, b)
+	}
+}
+
+func TestDevlinkRegionSnapshot(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkRegionSnapshot in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	regions, err := DevlinkRegionList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("devlink region count = ", len(regions))
+	for _, r := range regions {
+		t.Logf("Region: %+v", *r)
+	}
+	if len(regions) == 0 {
+		t.Skip("device has no regions")
+	}
+
+	region := regions[0]
+	regionAttrs := DevlinkRegionAttrs{
+		Name:           region.Name,
+		PortIndex:      region.PortIndex,
+		PortIndexValid: region.PortIndexValid,
+	}
+	id, err := DevlinkRegionSnapshotNew(socket, bus, device, regionAttrs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	readAttrs := DevlinkRegionReadAttrs{SnapshotID: id, Address: 0, Length: 16}
+	err = DevlinkRegionRead(socket, bus, device, regionAttrs, readAttrs, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.LessOrEqual(t, buf.Len(), 16)
+
+	err = DevlinkRegionSnapshotDel(socket, bus, device, regionAttrs, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDevlinkRateNode(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkRateNode in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addAttrs := DevlinkPortAddAttrs{
+		SfNumber:      uint32(sfnum),
+		PfNumber:      uint16(pfnum),
+		SfNumberValid: true,
+	}
+	port, err := DevlinkPortAdd(socket, bus, device, DEVLINK_PORT_FLAVOUR_PCI_SF, addAttrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := DevlinkPortDel(socket, bus, device, port.PortIndex); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	nodeAttrs := DevlinkRateSetAttrs{TxMax: 125000000, TxMaxValid: true}
+	err = DevlinkRateNodeAdd(socket, bus, device, "testgroup", nodeAttrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := DevlinkRateNodeDel(socket, bus, device, "testgroup"); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	node, err := DevlinkRateNodeGet(socket, bus, device, "testgroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint16(DEVLINK_RATE_TYPE_NODE), node.Type, "miss-matching rate type")
+	assert.Equal(t, nodeAttrs.TxMax, node.TxMax, "miss-matching node tx_max")
+
+	leafAttrs := DevlinkRateSetAttrs{
+		TxShare:      12500000,
+		Parent:       "testgroup",
+		TxShareValid: true,
+		ParentValid:  true,
+	}
+	err = DevlinkPortRateSet(socket, bus, device, port.PortIndex, leafAttrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf, err := DevlinkPortRateGet(socket, bus, device, port.PortIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, leafAttrs.TxShare, leaf.TxShare, "miss-matching leaf tx_share")
+	assert.Equal(t, "testgroup", leaf.Parent, "miss-matching leaf parent")
+
+	// detach the leaf so the node can be deleted
+	err = DevlinkPortRateSet(socket, bus, device, port.PortIndex, DevlinkRateSetAttrs{ParentValid: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDevlinkTrapList(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkTrapList in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	traps, err := DevlinkTrapList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("devlink trap count = ", len(traps))
+	for _, trap := range traps {
+		t.Logf("Trap: %+v", *trap)
+		if trap.Action == "unknown" {
+			continue
+		}
+		err = DevlinkTrapSet(socket, bus, device, trap.Name, trap.Action)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	groups, err := DevlinkTrapGroupList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, group := range groups {
+		t.Logf("Trap Group: %+v", *group)
+	}
+
+	policers, err := DevlinkTrapPolicerList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, policer := range policers {
+		t.Logf("Trap Policer: %+v", *policer)
+	}
+}
+
+func TestDevlinkSbOccupancy(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkSbOccupancy in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sbs, err := DevlinkSbList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sbs) == 0 {
+		t.Skip("device has no shared buffer")
+	}
+
+	pools, err := DevlinkSbPoolList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pool := range pools {
+		t.Logf("SB Pool: %+v", *pool)
+	}
+
+	err = DevlinkSbOccSnapshot(socket, bus, device, sbs[0].Index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pps, err := DevlinkSbPortPoolList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pp := range pps {
+		t.Logf("SB Port Pool: %+v", *pp)
+	}
+	tcs, err := DevlinkSbTcBindList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range tcs {
+		t.Logf("SB TC Bind: %+v", *tc)
+	}
+	err = DevlinkSbOccMaxClear(socket, bus, device, sbs[0].Index)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDevlinkDevParamList(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkDevParamList in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := DevlinkDevParamList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, param := range params {
+		t.Logf("Param: %+v", *param)
+	}
+}
+
+func devParamMsg(name string, paramType uint8, values map[uint8][]byte) []byte {
+	param := nl.NewRtAttr(DEVLINK_ATTR_PARAM|unix.NLA_F_NESTED, nil)
+	param.AddRtAttr(DEVLINK_ATTR_PARAM_NAME, nl.ZeroTerminated(name))
+	param.AddRtAttr(DEVLINK_ATTR_PARAM_GENERIC, nil)
+	param.AddRtAttr(DEVLINK_ATTR_PARAM_TYPE, nl.Uint8Attr(paramType))
+	list := param.AddRtAttr(DEVLINK_ATTR_PARAM_VALUES_LIST|unix.NLA_F_NESTED, nil)
+	for _, cmode := range []uint8{DEVLINK_PARAM_CMODE_RUNTIME, DEVLINK_PARAM_CMODE_DRIVERINIT} {
+		data, ok := values[cmode]
+		if !ok {
+			continue
+		}
+		value := list.AddRtAttr(DEVLINK_ATTR_PARAM_VALUE|unix.NLA_F_NESTED, nil)
+		value.AddRtAttr(DEVLINK_ATTR_PARAM_VALUE_CMODE, nl.Uint8Attr(cmode))
+		if data != nil {
+			value.AddRtAttr(DEVLINK_ATTR_PARAM_VALUE_DATA, data)
+		}
+	}
+
+	msg := make([]byte, nl.SizeofGenlmsg)
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_BUS_NAME, nl.ZeroTerminated("pci")).Serialize()...)
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_DEV_NAME, nl.ZeroTerminated("0000:08:00.0")).Serialize()...)
+	return append(msg, param.Serialize()...)
+}
+
+func TestParseDevlinkDevParamList(t *testing.T) {
+	msgs := [][]byte{
+		devParamMsg("max_macs", MNL_TYPE_U32, map[uint8][]byte{
+			DEVLINK_PARAM_CMODE_DRIVERINIT: nl.Uint32Attr(128),
+		}),
+		devParamMsg("enable_roce", MNL_TYPE_FLAG, map[uint8][]byte{
+			DEVLINK_PARAM_CMODE_RUNTIME:    nil,
+			DEVLINK_PARAM_CMODE_DRIVERINIT: {},
+		}),
+	}
+
+	params, err := parseDevlinkDevParamList(msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, params, 2)
+
+	assert.Equal(t, "0000:08:00.0", params[0].DeviceName)
+	assert.Equal(t, "max_macs", params[0].Name)
+	assert.True(t, params[0].Generic)
+	assert.Equal(t, []DevlinkDevParamValue{
+		{CMode: DEVLINK_PARAM_CMODE_DRIVERINIT, Type: MNL_TYPE_U32, Data: nl.Uint32Attr(128)},
+	}, params[0].Values)
+
+	assert.Equal(t, "enable_roce", params[1].Name)
+	assert.Equal(t, []DevlinkDevParamValue{
+		{CMode: DEVLINK_PARAM_CMODE_RUNTIME, Type: MNL_TYPE_FLAG, Data: []byte{0}},
+		{CMode: DEVLINK_PARAM_CMODE_DRIVERINIT, Type: MNL_TYPE_FLAG, Data: []byte{1}},
+	}, params[1].Values)
+}
+
+func TestDevlinkDevParamSetTyped(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkDevParamSetTyped in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []bool{true, false} {
+		err = DevlinkDevParamSetTyped(socket, bus, device, "disable_netdev", value, "runtime")
+		if err != nil {
+			t.Fatal(err)
+		}
+		param, err := DevlinkDevParamGet(socket, bus, device, "disable_netdev")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := param.Value().Bool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, value, got)
+	}
+
+	err = DevlinkDevParamSetTyped(socket, bus, device, "disable_netdev", "true", "runtime")
+	assert.Error(t, err, "string value for a flag parameter must fail")
+}
+
+func TestDevlinkDevParamValue(t *testing.T) {
+	value, err := paramStringToValue(MNL_TYPE_U64, "18446744073709551615")
+	assert.NoError(t, err)
+	data, err := paramValueData(MNL_TYPE_U64, value)
+	assert.NoError(t, err)
+	u64, err := DevlinkDevParamValue{Type: MNL_TYPE_U64, Data: data}.Uint64()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(18446744073709551615), u64)
+
+	_, err = paramStringToValue(MNL_TYPE_U32, "-1")
+	assert.Error(t, err, "negative value must fail")
+	_, err = paramStringToValue(MNL_TYPE_U8, "256")
+	assert.Error(t, err, "out of range value must fail")
+	_, err = paramValueData(MNL_TYPE_U16, -1)
+	assert.Error(t, err, "negative value must fail")
+	_, err = paramValueData(MNL_TYPE_U16, uint32(65536))
+	assert.Error(t, err, "out of range value must fail")
+	_, err = paramValueData(MNL_TYPE_STRING, 5)
+	assert.Error(t, err, "integer value for a string parameter must fail")
+
+	data, err = paramValueData(MNL_TYPE_U16, 300)
+	assert.NoError(t, err)
+	typed, err := DevlinkDevParamValue{Type: MNL_TYPE_U16, Data: data}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(300), typed)
+
+	data, err = paramValueData(MNL_TYPE_STRING, "eth")
+	assert.NoError(t, err)
+	str, err := DevlinkDevParamValue{Type: MNL_TYPE_STRING, Data: data}.StringValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "eth", str)
+
+	data, err = paramValueData(MNL_TYPE_FLAG, false)
+	assert.NoError(t, err)
+	assert.Nil(t, data, "false flag must not have data")
+	data, err = paramValueData(MNL_TYPE_FLAG, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, data, "true flag must have empty data")
+
+	_, err = DevlinkDevParamValue{Type: MNL_TYPE_FLAG, Data: []byte{1}}.Uint8()
+	assert.Error(t, err, "type mismatch must fail")
+
+	cmode, err := cmodeStringToMode("permanent")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(DEVLINK_PARAM_CMODE_PERMANENT), cmode)
+}
+
+func TestDevlinkPortParamList(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkPortParamList in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := DevlinkPortParamList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, param := range params {
+		t.Logf("Port Param: %+v", *param)
+		if !param.PortIndexValid {
+			t.Fatalf("port param %s without port index", param.Name)
+		}
+		_, err = DevlinkPortParamGet(socket, bus, device, param.PortIndex, param.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDevlinkResourceSet(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkResourceSet in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := DevlinkGetDeviceResources(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Resources) == 0 {
+		t.Skip("device has no resources")
+	}
+
+	// setting the current size again must not require a reload
+	resource := res.Resources[0]
+	reload, err := DevlinkResourceSet(socket, bus, device, "/"+resource.Name, resource.SizeNew)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, resource.PendingChange, reload)
+
+	_, err = DevlinkResourceSet(socket, bus, device, "/"+resource.Name, resource.SizeMax+1)
+	assert.Error(t, err, "size above max must fail")
+}
+
+func TestDevlinkResourceFind(t *testing.T) {
+	res := DevlinkResources{
+		Resources: []DevlinkResource{
+			{Name: "max_local_SFs", ID: 1, SizeMax: 256, SizeGranularity: 1},
+			{Name: "kvd", ID: 2, Children: []DevlinkResource{
+				{Name: "linear", ID: 3, SizeMin: 16, SizeMax: 1024, SizeGranularity: 16},
+			}},
+		},
+	}
+
+	resource, err := res.ResourceByPath("/kvd/linear")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), resource.ID)
+	resource, err = res.ResourceByPath("max_local_SFs")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), resource.ID)
+	_, err = res.ResourceByPath("/kvd/hash")
+	assert.Error(t, err)
+	_, err = res.ResourceByPath("/")
+	assert.Error(t, err)
+
+	linear, _ := res.ResourceByPath("/kvd/linear")
+	assert.NoError(t, linear.validateSize(64))
+	assert.Error(t, linear.validateSize(8), "size below min must fail")
+	assert.Error(t, linear.validateSize(2048), "size above max must fail")
+	assert.Error(t, linear.validateSize(40), "size not a multiple of granularity must fail")
+}
+
+func resourceAttr(name string, id uint64, size uint64, occ uint64, children ...*nl.RtAttr) *nl.RtAttr {
+	resource := nl.NewRtAttr(DEVLINK_ATTR_RESOURCE, nil)
+	resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_NAME, nl.ZeroTerminated(name))
+	resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_ID, nl.Uint64Attr(id))
+	resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_SIZE, nl.Uint64Attr(size))
+	resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_SIZE_GRAN, nl.Uint64Attr(1))
+	resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_UNIT, nl.Uint8Attr(DEVLINK_RESOURCE_UNIT_ENTRY))
+	resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_SIZE_MIN, nl.Uint64Attr(0))
+	resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_SIZE_MAX, nl.Uint64Attr(size))
+	resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_OCC, nl.Uint64Attr(occ))
+	if len(children) > 0 {
+		list := resource.AddRtAttr(DEVLINK_ATTR_RESOURCE_LIST, nil)
+		for _, child := range children {
+			list.AddChild(child)
+		}
+	}
+	return resource
+}
+
+func TestDevlinkResourceTree(t *testing.T) {
+	// the kernel nests the resource attributes without NLA_F_NESTED
+	list := nl.NewRtAttr(DEVLINK_ATTR_RESOURCE_LIST, nil)
+	list.AddChild(resourceAttr("max_local_SFs", 1, 256, 200))
+	list.AddChild(resourceAttr("kvd", 2, 1024, 0,
+		resourceAttr("linear", 3, 512, 0,
+			resourceAttr("singles", 4, 256, 16),
+			resourceAttr("chunks", 5, 256, 300))))
+
+	msg := nl.NewRtAttr(DEVLINK_ATTR_BUS_NAME, nl.ZeroTerminated("pci")).Serialize()
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_DEV_NAME, nl.ZeroTerminated("0000:08:00.0")).Serialize()...)
+	msg = append(msg, list.Serialize()...)
+	attrs, err := nl.ParseRouteAttrAsMap(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res DevlinkResources
+	if err = res.parseAttributes(attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	singles, err := res.ResourceByID(4)
+	assert.NoError(t, err)
+	assert.Equal(t, "/kvd/linear/singles", singles.Path())
+	linear, err := res.ResourceByPath("/kvd/linear")
+	assert.NoError(t, err)
+	assert.Same(t, linear, singles.Parent, "parent must point into the resource tree")
+	assert.Same(t, &res.Resources[1], linear.Parent, "parent must point into the resource tree")
+	assert.Nil(t, res.Resources[1].Parent)
+	_, err = res.ResourceByID(42)
+	assert.Error(t, err)
+
+	var paths []string
+	err = res.Walk(func(r *DevlinkResource) error {
+		paths = append(paths, r.Path())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/max_local_SFs", "/kvd", "/kvd/linear", "/kvd/linear/singles", "/kvd/linear/chunks"}, paths)
+
+	stop := errors.New("stop")
+	visited := 0
+	err = res.Walk(func(r *DevlinkResource) error {
+		visited++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, visited)
+
+	free, ok := res.Resources[0].Free()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(56), free)
+	chunks, _ := res.ResourceByPath("/kvd/linear/chunks")
+	free, ok = chunks.Free()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), free, "over-occupied resource has no free capacity")
+	free, ok = (&DevlinkResource{Size: 8}).Free()
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), free)
+}
+
+func TestDevlinkPortSplit(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkPortSplit in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ports, err := DevlinkGetAllPortList(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var port *DevlinkPort
+	for _, p := range ports {
+		if p.BusName == bus && p.DeviceName == device && p.Splittable && p.Lanes > 1 {
+			port = p
+			break
+		}
+	}
+	if port == nil {
+		t.Skip("device has no splittable port")
+	}
+
+	err = DevlinkPortSplit(socket, bus, device, port.PortIndex, port.Lanes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports, err = DevlinkGetAllPortList(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var splitPort *DevlinkPort
+	for _, p := range ports {
+		if p.BusName == bus && p.DeviceName == device && p.Split && p.PortNumber == port.PortNumber {
+			splitPort = p
+			break
+		}
+	}
+	if splitPort == nil {
+		t.Fatal("split port not found")
+	}
+
+	err = DevlinkPortUnsplit(socket, bus, device, splitPort.PortIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDevlinkPortSetType(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkPortSetType in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ports, err := DevlinkGetAllPortList(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, port := range ports {
+		if port.BusName != bus || port.DeviceName != device || port.PortFlavour != DEVLINK_PORT_FLAVOUR_PHYSICAL {
+			continue
+		}
+		// only an eth or ib port type can be set back as is
+		if port.PortType == DEVLINK_PORT_TYPE_NOTSET || port.PortType == DEVLINK_PORT_TYPE_AUTO {
+			continue
+		}
+		err = DevlinkPortSetType(socket, bus, device, port.PortIndex, port.PortType.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	t.Skip("device has no physical eth or ib port")
+}
+
+func TestDevlinkPortTypeFlavourString(t *testing.T) {
+	port := DevlinkPort{PortType: DEVLINK_PORT_TYPE_ETH, PortFlavour: DEVLINK_PORT_FLAVOUR_PCI_SF}
+	assert.Equal(t, "eth", port.PortType.String())
+	assert.Equal(t, "pcisf", port.PortFlavour.String())
+	assert.Equal(t, "unknown", PortFlavour(42).String())
+
+	b, err := json.Marshal(struct {
+		Type    PortType
+		Flavour PortFlavour
+	}{port.PortType, port.PortFlavour})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Type":"eth","Flavour":"pcisf"}`, string(b))
+
+	for _, name := range []string{"eth", "ib", "auto"} {
+		portType, err := portStringToType(name)
+		assert.NoError(t, err)
+		assert.Equal(t, name, PortType(portType).String())
+	}
+	_, err = portStringToType("notset")
+	assert.Error(t, err)
+}
+
+func TestDevlinkPortParseVfExternal(t *testing.T) {
+	var msg []byte
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_PORT_INDEX, nl.Uint32Attr(131073)).Serialize()...)
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_PORT_FLAVOUR, nl.Uint16Attr(DEVLINK_PORT_FLAVOUR_PCI_VF)).Serialize()...)
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_PORT_CONTROLLER_NUMBER, nl.Uint32Attr(1)).Serialize()...)
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_PORT_PCI_PF_NUMBER, nl.Uint16Attr(0)).Serialize()...)
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_PORT_PCI_VF_NUMBER, nl.Uint16Attr(3)).Serialize()...)
+	msg = append(msg, nl.NewRtAttr(DEVLINK_ATTR_PORT_EXTERNAL, nl.Uint8Attr(1)).Serialize()...)
+	attrs, err := nl.ParseRouteAttr(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port := &DevlinkPort{}
+	if err = port.parseAttributes(GENL_DEVLINK_NAME, attrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, PortFlavour(DEVLINK_PORT_FLAVOUR_PCI_VF), port.PortFlavour)
+	assert.Equal(t, uint32(1), port.Controller)
+	assert.Equal(t, uint16(3), port.VfNumber)
+	assert.True(t, port.External)
+}
+
+func TestDevlinkPortFnCaps(t *testing.T) {
+	fn := nl.NewRtAttr(DEVLINK_ATTR_PORT_FUNCTION|unix.NLA_F_NESTED, nil)
+	fn.AddRtAttr(DEVLINK_PORT_FN_ATTR_CAPS, bitfield32Attr(DEVLINK_PORT_FN_CAP_ROCE|DEVLINK_PORT_FN_CAP_IPSEC_PACKET, 0xf))
+	fn.AddRtAttr(DEVLINK_PORT_FN_ATTR_MAX_IO_EQS, nl.Uint32Attr(32))
+	attrs, err := nl.ParseRouteAttr(fn.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port := &DevlinkPort{}
+	if err = port.parseAttributes(GENL_DEVLINK_NAME, attrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, port.Fn.Roce)
+	assert.False(t, port.Fn.Migratable)
+	assert.False(t, port.Fn.IpsecCrypto)
+	assert.True(t, port.Fn.IpsecPacket)
+	assert.Equal(t, uint32(32), port.Fn.MaxIOEqs)
+	assert.Equal(t, uint8(0), port.Fn.Trust)
+
+	// the same attribute type carries the trust mode on 'mlxdevm'
+	fn = nl.NewRtAttr(DEVLINK_ATTR_PORT_FUNCTION|unix.NLA_F_NESTED, nil)
+	fn.AddRtAttr(MLXDEVM_PORT_FN_ATTR_TRUST, nl.Uint8Attr(1))
+	attrs, err = nl.ParseRouteAttr(fn.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port = &DevlinkPort{}
+	if err = port.parseAttributes(GENL_MLXDEVM_NAME, attrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint8(1), port.Fn.Trust)
+	assert.False(t, port.Fn.Roce)
+
+	setAttrs := DevlinkPortFnSetAttrs{
+		FnAttrs:         DevlinkPortFn{Roce: false, Migratable: true},
+		RoceValid:       true,
+		MigratableValid: true,
+	}
+	value, selector := setAttrs.capsBitfield()
+	assert.Equal(t, uint32(DEVLINK_PORT_FN_CAP_MIGRATABLE), value)
+	assert.Equal(t, uint32(DEVLINK_PORT_FN_CAP_ROCE|DEVLINK_PORT_FN_CAP_MIGRATABLE), selector)
+
+	err = DevlinkPortFnSet(GENL_MLXDEVM_NAME, "pci", "0000:08:00.0", 1, setAttrs)
+	assert.Error(t, err, "migratable must not be supported on 'mlxdevm'")
+
+	setAttrs = DevlinkPortFnSetAttrs{
+		FnAttrs:    DevlinkPortFn{Roce: true, State: DEVLINK_PORT_FN_STATE_ACTIVE},
+		RoceValid:  true,
+		StateValid: true,
+	}
+	err = DevlinkPortFnSet(GENL_MLXDEVM_NAME, "pci", "0000:08:00.0", 1, setAttrs)
+	assert.Error(t, err, "roce must not be combined with other attributes on 'mlxdevm'")
+}
+
+func TestDevlinkPortFnCapUCList(t *testing.T) {
+	fn := nl.NewRtAttr(DEVLINK_ATTR_PORT_FUNCTION|unix.NLA_F_NESTED, nil)
+	fn.AddRtAttr(DEVLINK_PORT_FN_ATTR_EXT_CAP_ROCE, nl.Uint8Attr(1))
+	fn.AddRtAttr(DEVLINK_PORT_FN_ATTR_EXT_CAP_UC_LIST, nl.Uint32Attr(1024))
+	attrs, err := nl.ParseRouteAttr(fn.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port := &DevlinkPort{}
+	if err = port.parseAttributes(GENL_MLXDEVM_NAME, attrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &DevlinkPortFnCap{Roce: true, UCList: 1024}, port.PortCap)
+
+	setAttrs := DevlinkPortFnCapSetAttrs{
+		FnCapAttrs:  DevlinkPortFnCap{Roce: true, UCList: 1024},
+		RoceValid:   true,
+		UCListValid: true,
+	}
+	assert.NoError(t, setAttrs.verify(port.PortCap))
+	setAttrs.FnCapAttrs.UCList = 64
+	assert.Error(t, setAttrs.verify(port.PortCap), "mismatching max_uc_macs must fail")
+	setAttrs.UCListValid = false
+	assert.NoError(t, setAttrs.verify(port.PortCap), "attributes not set must not be verified")
+}
+
+func TestDevlinkLinecardList(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkLinecardList in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linecards, err := DevlinkLinecardList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lc := range linecards {
+		t.Logf("Line card: %+v", *lc)
+		if lc.Nested != nil {
+			t.Logf("Line card %d device: %s/%s", lc.Index, lc.Nested.BusName, lc.Nested.DeviceName)
+		}
+	}
+}
+
+func TestDevlinkSelftestsRun(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkSelftestsRun in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	selftests, err := DevlinkSelftestsGet(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selftests) == 0 {
+		t.Skip("device does not support selftests")
+	}
+
+	results, err := DevlinkSelftestsRun(socket, bus, device, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range selftests {
+		t.Logf("Selftest %s: %s", name, results[name])
+	}
+}
+
+func TestParseDevlinkSelftestResults(t *testing.T) {
+	selftests := nl.NewRtAttr(DEVLINK_ATTR_SELFTESTS|unix.NLA_F_NESTED, nil)
+	result := selftests.AddRtAttr(DEVLINK_ATTR_SELFTEST_RESULT|unix.NLA_F_NESTED, nil)
+	result.AddRtAttr(DEVLINK_ATTR_SELFTEST_RESULT_ID, nl.Uint32Attr(DEVLINK_ATTR_SELFTEST_ID_FLASH))
+	result.AddRtAttr(DEVLINK_ATTR_SELFTEST_RESULT_STATUS, nl.Uint8Attr(DEVLINK_SELFTEST_STATUS_PASS))
+	msg := make([]byte, nl.SizeofGenlmsg)
+	msg = append(msg, selftests.Serialize()...)
+
+	results, err := parseDevlinkSelftestResults([][]byte{msg})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"flash": "pass"}, results)
+
+	supported := nl.NewRtAttr(DEVLINK_ATTR_SELFTESTS|unix.NLA_F_NESTED, nil)
+	supported.AddRtAttr(DEVLINK_ATTR_SELFTEST_ID_FLASH, nil)
+	msg = make([]byte, nl.SizeofGenlmsg)
+	msg = append(msg, supported.Serialize()...)
+
+	names, err := parseDevlinkSelftests([][]byte{msg})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"flash"}, names)
+
+	supported.AddRtAttr(DEVLINK_ATTR_SELFTEST_ID_FLASH+1, nil)
+	msg = make([]byte, nl.SizeofGenlmsg)
+	msg = append(msg, supported.Serialize()...)
+
+	ids, err := parseDevlinkSelftestIDs([][]byte{msg})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{DEVLINK_ATTR_SELFTEST_ID_FLASH, DEVLINK_ATTR_SELFTEST_ID_FLASH + 1}, ids)
+
+	_, err = selftestStringToID("memory")
+	assert.Error(t, err)
+}
+
+func TestDevlinkDpipeTables(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkDpipeTables in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := DevlinkDpipeTableList(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) == 0 {
+		t.Skip("device does not have dpipe tables")
+	}
+
+	headers, err := DevlinkDpipeHeaders(socket, bus, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, header := range headers {
+		t.Logf("Dpipe header %s id %d fields %d", header.Name, header.ID, len(header.Fields))
+	}
+
+	for _, table := range tables {
+		got, err := DevlinkDpipeTableGet(socket, bus, device, table.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, table.Name, got.Name)
+
+		entries, err := DevlinkDpipeEntries(socket, bus, device, table.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("Dpipe table %s size %d entries %d", table.Name, table.Size, len(entries))
+	}
+}
+
+func dpipeFieldRef(refType uint16, typeAttr uint16, headerID uint32, fieldID uint32) *nl.RtAttr {
+	ref := nl.NewRtAttr(int(refType)|unix.NLA_F_NESTED, nil)
+	ref.AddRtAttr(int(typeAttr), nl.Uint32Attr(0))
+	ref.AddRtAttr(DEVLINK_ATTR_DPIPE_HEADER_ID, nl.Uint32Attr(headerID))
+	ref.AddRtAttr(DEVLINK_ATTR_DPIPE_HEADER_GLOBAL, nl.Uint8Attr(1))
+	ref.AddRtAttr(DEVLINK_ATTR_DPIPE_HEADER_INDEX, nl.Uint32Attr(0))
+	ref.AddRtAttr(DEVLINK_ATTR_DPIPE_FIELD_ID, nl.Uint32Attr(fieldID))
+	return ref
+}
+
+func TestParseDevlinkDpipe(t *testing.T) {
+	tables := nl.NewRtAttr(DEVLINK_ATTR_DPIPE_TABLES|unix.NLA_F_NESTED, nil)
+	table := tables.AddRtAttr(DEVLINK_ATTR_DPIPE_TABLE|unix.NLA_F_NESTED, nil)
+	table.AddRtAttr(DEVLINK_ATTR_DPIPE_TABLE_NAME, nl.ZeroTerminated("mlxsw_erif"))
+	table.AddRtAttr(DEVLINK_ATTR_DPIPE_TABLE_SIZE, nl.Uint64Attr(1000))
+	table.AddRtAttr(DEVLINK_ATTR_DPIPE_TABLE_COUNTERS_ENABLED, nl.Uint8Attr(1))
+	table.AddRtAttr(DEVLINK_ATTR_DPIPE_TABLE_RESOURCE_ID, nl.Uint64Attr(3))
+	table.AddRtAttr(DEVLINK_ATTR_DPIPE_TABLE_RESOURCE_UNITS, nl.Uint64Attr(1))
+	matches := table.AddRtAttr(DEVLINK_ATTR_DPIPE_TABLE_MATCHES|unix.NLA_F_NESTED, nil)
+	matches.AddChild(dpipeFieldRef(DEVLINK_ATTR_DPIPE_MATCH, DEVLINK_ATTR_DPIPE_MATCH_TYPE, 0, 1))
+	actions := table.AddRtAttr(DEVLINK_ATTR_DPIPE_TABLE_ACTIONS|unix.NLA_F_NESTED, nil)
+	actions.AddChild(dpipeFieldRef(DEVLINK_ATTR_DPIPE_ACTION, DEVLINK_ATTR_DPIPE_ACTION_TYPE, 1, 2))
+	msg := make([]byte, nl.SizeofGenlmsg)
+	msg = append(msg, tables.Serialize()...)
+
+	parsed, err := parseDevlinkDpipeTableList([][]byte{msg})
+	assert.NoError(t, err)
+	assert.Len(t, parsed, 1)
+	assert.Equal(t, "mlxsw_erif", parsed[0].Name)
+	assert.Equal(t, uint64(1000), parsed[0].Size)
+	assert.True(t, parsed[0].CountersEnabled)
+	assert.True(t, parsed[0].ResourceValid)
+	assert.Equal(t, uint64(3), parsed[0].ResourceID)
+	assert.Equal(t, []DevlinkDpipeFieldRef{{HeaderGlobal: true, FieldID: 1}}, parsed[0].Matches)
+	assert.Equal(t, []DevlinkDpipeFieldRef{{HeaderID: 1, HeaderGlobal: true, FieldID: 2}}, parsed[0].Actions)
+
+	entries := nl.NewRtAttr(DEVLINK_ATTR_DPIPE_ENTRIES|unix.NLA_F_NESTED, nil)
+	entry := entries.AddRtAttr(DEVLINK_ATTR_DPIPE_ENTRY|unix.NLA_F_NESTED, nil)
+	entry.AddRtAttr(DEVLINK_ATTR_DPIPE_ENTRY_INDEX, nl.Uint64Attr(7))
+	matchValues := entry.AddRtAttr(DEVLINK_ATTR_DPIPE_ENTRY_MATCH_VALUES|unix.NLA_F_NESTED, nil)
+	matchValue := matchValues.AddRtAttr(DEVLINK_ATTR_DPIPE_MATCH_VALUE|unix.NLA_F_NESTED, nil)
+	matchValue.AddChild(dpipeFieldRef(DEVLINK_ATTR_DPIPE_MATCH, DEVLINK_ATTR_DPIPE_MATCH_TYPE, 0, 1))
+	matchValue.AddRtAttr(DEVLINK_ATTR_DPIPE_VALUE, nl.Uint32Attr(42))
+	matchValue.AddRtAttr(DEVLINK_ATTR_DPIPE_VALUE_MAPPING, nl.Uint32Attr(5))
+	entry.AddRtAttr(DEVLINK_ATTR_DPIPE_ENTRY_COUNTER, nl.Uint64Attr(99))
+	msg = make([]byte, nl.SizeofGenlmsg)
+	msg = append(msg, entries.Serialize()...)
+
+	parsedEntries, err := parseDevlinkDpipeEntryList([][]byte{msg})
+	assert.NoError(t, err)
+	assert.Len(t, parsedEntries, 1)
+	assert.Equal(t, uint64(7), parsedEntries[0].Index)
+	assert.True(t, parsedEntries[0].CounterValid)
+	assert.Equal(t, uint64(99), parsedEntries[0].Counter)
+	assert.Len(t, parsedEntries[0].MatchValues, 1)
+	assert.Equal(t, nl.Uint32Attr(42), parsedEntries[0].MatchValues[0].Value)
+	assert.True(t, parsedEntries[0].MatchValues[0].MappingValid)
+	assert.Equal(t, uint32(5), parsedEntries[0].MatchValues[0].Mapping)
+	assert.Equal(t, uint32(1), parsedEntries[0].MatchValues[0].Field.FieldID)
+}
+
+func TestDevlinkNestedDevlinkParse(t *testing.T) {
+	fn := nl.NewRtAttr(DEVLINK_ATTR_PORT_FUNCTION|unix.NLA_F_NESTED, nil)
+	fnDevlink := fn.AddRtAttr(DEVLINK_PORT_FN_ATTR_DEVLINK|unix.NLA_F_NESTED, nil)
+	fnDevlink.AddRtAttr(DEVLINK_ATTR_BUS_NAME, nl.ZeroTerminated("auxiliary"))
+	fnDevlink.AddRtAttr(DEVLINK_ATTR_DEV_NAME, nl.ZeroTerminated("mlx5_core.sf.2"))
+	attrs, err := nl.ParseRouteAttr(fn.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port := &DevlinkPort{}
+	if err = port.parseAttributes(GENL_DEVLINK_NAME, attrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &DevlinkNestedDevice{BusName: "auxiliary", DeviceName: "mlx5_core.sf.2"}, port.Fn.Devlink)
+
+	port = &DevlinkPort{}
+	if err = port.parseAttributes(GENL_MLXDEVM_NAME, attrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, port.Fn)
+
+	// an attribute header claiming more data than the nest holds
+	malformed := nl.NewRtAttr(DEVLINK_ATTR_PORT_FUNCTION|unix.NLA_F_NESTED, nil)
+	malformed.AddRtAttr(DEVLINK_PORT_FN_ATTR_DEVLINK|unix.NLA_F_NESTED, []byte{8, 0, 1, 0})
+	attrs, err = nl.ParseRouteAttr(malformed.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port = &DevlinkPort{}
+	assert.Error(t, port.parseAttributes(GENL_DEVLINK_NAME, attrs))
+
+	nested := nl.NewRtAttr(DEVLINK_ATTR_NESTED_DEVLINK|unix.NLA_F_NESTED, nil)
+	nested.AddRtAttr(DEVLINK_ATTR_BUS_NAME, nl.ZeroTerminated("auxiliary"))
+	nested.AddRtAttr(DEVLINK_ATTR_DEV_NAME, nl.ZeroTerminated("mlxsw_core.lc.1"))
+	nested.AddRtAttr(DEVLINK_ATTR_NETNS_ID, nl.Uint32Attr(2))
+	attrs, err = nl.ParseRouteAttr(nested.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev := &DevlinkDevice{}
+	if err = dev.parseAttributes(attrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []DevlinkNestedDevice{{BusName: "auxiliary", DeviceName: "mlxsw_core.lc.1", NetnsID: 2, NetnsIDValid: true}}, dev.NestedDevlinks)
+
+	_, err = DevlinkGetPortFnDevice(GENL_DEVLINK_NAME, &DevlinkPort{})
+	assert.Error(t, err)
+}
+
+func TestDevlinkGetPortList(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping test TestDevlinkGetPortList in CI environment until test is fixed")
+	}
+
+	err := validateArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ports, err := DevlinkGetPortList(socket, bus, device, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, port := range ports {
+		assert.Equal(t, bus, port.BusName)
+		assert.Equal(t, device, port.DeviceName)
+	}
+
+	filter := &DevlinkPortFilter{
+		Flavour:       DEVLINK_PORT_FLAVOUR_PCI_SF,
+		PfNumber:      uint16(pfnum),
+		FlavourValid:  true,
+		PfNumberValid: true,
+	}
+	sfPorts, err := DevlinkGetPortList(socket, bus, device, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, port := range sfPorts {
+		assert.Equal(t, PortFlavour(DEVLINK_PORT_FLAVOUR_PCI_SF), port.PortFlavour)
+		assert.Equal(t, uint16(pfnum), port.PfNumber)
+	}
+	t.Logf("Device has %d ports, %d SFs on pf %d", len(ports), len(sfPorts), pfnum)
+}
+
+func TestDevlinkPortFilterMatch(t *testing.T) {
+	sf := &DevlinkPort{PortFlavour: DEVLINK_PORT_FLAVOUR_PCI_SF, PfNumber: 0, SfNumber: 88, Controller: 1,
+		PfNumberValid: true, SfNumberValid: true, ControllerValid: true}
+	pf := &DevlinkPort{PortFlavour: DEVLINK_PORT_FLAVOUR_PCI_PF, PfNumber: 0, PfNumberValid: true}
+	physical := &DevlinkPort{PortFlavour: DEVLINK_PORT_FLAVOUR_PHYSICAL}
+
+	var filter *DevlinkPortFilter
+	assert.True(t, filter.match(sf))
+	assert.True(t, filter.match(pf))
+
+	filter = &DevlinkPortFilter{Flavour: DEVLINK_PORT_FLAVOUR_PCI_SF, FlavourValid: true, PfNumberValid: true}
+	assert.True(t, filter.match(sf))
+	assert.False(t, filter.match(pf))
+
+	filter.SfNumber = 89
+	filter.SfNumberValid = true
+	assert.False(t, filter.match(sf))
+
+	filter = &DevlinkPortFilter{Controller: 1, ControllerValid: true}
+	assert.True(t, filter.match(sf))
+	assert.False(t, filter.match(pf))
+
+	// ports not reporting an attribute do not match it, even for value 0
+	filter = &DevlinkPortFilter{PfNumberValid: true}
+	assert.True(t, filter.match(pf))
+	assert.False(t, filter.match(physical))
+	filter = &DevlinkPortFilter{SfNumberValid: true}
+	assert.False(t, filter.match(pf))
+}
+
 var socket string
 var bus string
 var device string
 var sfnum uint
 var pfnum uint
+var flashfile string
 
 func init() {
 	flag.StringVar(&socket, "socketname", "mlxdevm", "socket name as devlink or mlxdevm")
@@ -306,4 +1652,5 @@ func init() {
 	flag.StringVar(&device, "device", "", "devlink device devicename")
 	flag.UintVar(&pfnum, "pfnum", 0, "devlink port pfnumber")
 	flag.UintVar(&sfnum, "sfnum", 0, "devlink port sfnumber")
+	flag.StringVar(&flashfile, "flashfile", "", "firmware file name to flash, relative to /lib/firmware")
 }
@@ -18,6 +18,10 @@ var pkgHandle = &Handle{}
 // which gets released when the handle is deleted.
 type Handle struct {
 	sockets map[int]*nl.SocketHandle
+	// ns is the network namespace of sockets opened outside of sockets,
+	// e.g. for multicast notifications, when nsValid is set
+	ns      netns.NsHandle
+	nsValid bool
 }
 
 // SetSocketTimeout configures timeout for default netlink sockets
@@ -121,6 +125,15 @@ func NewHandleAtFrom(newNs, curNs netns.NsHandle) (*Handle, error) {
 
 func newHandle(newNs, curNs netns.NsHandle, nlFamilies ...int) (*Handle, error) {
 	h := &Handle{sockets: map[int]*nl.SocketHandle{}}
+	if newNs.IsOpen() {
+		// the caller may close newNs once the handle is created
+		fd, err := unix.Dup(int(newNs))
+		if err != nil {
+			return nil, err
+		}
+		h.ns = netns.NsHandle(fd)
+		h.nsValid = true
+	}
 	fams := nl.SupportedNlFamilies
 	if len(nlFamilies) != 0 {
 		fams = nlFamilies
@@ -128,6 +141,7 @@ func newHandle(newNs, curNs netns.NsHandle, nlFamilies ...int) (*Handle, error)
 	for _, f := range fams {
 		s, err := nl.GetNetlinkSocketAt(newNs, curNs, f)
 		if err != nil {
+			h.Delete()
 			return nil, err
 		}
 		h.sockets[f] = &nl.SocketHandle{Socket: s}
@@ -141,6 +155,19 @@ func (h *Handle) Delete() {
 		sh.Close()
 	}
 	h.sockets = nil
+	if h.nsValid {
+		h.ns.Close()
+		h.nsValid = false
+	}
+}
+
+// nsHandle returns the network namespace of the handle, or netns.None() for the
+// current network namespace
+func (h *Handle) nsHandle() netns.NsHandle {
+	if !h.nsValid {
+		return netns.None()
+	}
+	return h.ns
 }
 
 func (h *Handle) newNetlinkRequest(proto, flags int) *nl.NetlinkRequest {
@@ -10,59 +10,271 @@ const (
 )
 
 const (
-	DEVLINK_CMD_GET           = 1
-	DEVLINK_CMD_PORT_GET      = 5
-	DEVLINK_CMD_PORT_SET      = 6
-	DEVLINK_CMD_PORT_NEW      = 7
-	DEVLINK_CMD_PORT_DEL      = 8
-	DEVLINK_CMD_ESWITCH_GET   = 29
-	DEVLINK_CMD_ESWITCH_SET   = 30
-	DEVLINK_CMD_RESOURCE_DUMP = 36
-	DEVLINK_CMD_PARAM_GET     = 38
-	DEVLINK_CMD_PARAM_SET     = 39
-	DEVLINK_CMD_EXT_CAP_SET   = 161
-)
-
-const (
-	DEVLINK_ATTR_BUS_NAME                   = 1
-	DEVLINK_ATTR_DEV_NAME                   = 2
-	DEVLINK_ATTR_PORT_INDEX                 = 3
-	DEVLINK_ATTR_PORT_TYPE                  = 4
-	DEVLINK_ATTR_PORT_NETDEV_IFINDEX        = 6
-	DEVLINK_ATTR_PORT_NETDEV_NAME           = 7
-	DEVLINK_ATTR_PORT_IBDEV_NAME            = 8
-	DEVLINK_ATTR_ESWITCH_MODE               = 25
-	DEVLINK_ATTR_ESWITCH_INLINE_MODE        = 26
-	DEVLINK_ATTR_ESWITCH_ENCAP_MODE         = 62
-	DEVLINK_ATTR_RESOURCE_LIST              = 63 /* nested */
-	DEVLINK_ATTR_RESOURCE                   = 64 /* nested */
-	DEVLINK_ATTR_RESOURCE_NAME              = 65 /* string */
-	DEVLINK_ATTR_RESOURCE_ID                = 66 /* u64 */
-	DEVLINK_ATTR_RESOURCE_SIZE              = 67 /* u64 */
-	DEVLINK_ATTR_RESOURCE_SIZE_NEW          = 68 /* u64 */
-	DEVLINK_ATTR_RESOURCE_SIZE_VALID        = 69 /* u8 */
-	DEVLINK_ATTR_RESOURCE_SIZE_MIN          = 70 /* u64 */
-	DEVLINK_ATTR_RESOURCE_SIZE_MAX          = 71 /* u64 */
-	DEVLINK_ATTR_RESOURCE_SIZE_GRAN         = 72 /* u64 */
-	DEVLINK_ATTR_RESOURCE_UNIT              = 73 /* u8 */
-	DEVLINK_ATTR_RESOURCE_OCC               = 74 /* u64 */
-	DEVLINK_ATTR_DPIPE_TABLE_RESOURCE_ID    = 75 /* u64 */
-	DEVLINK_ATTR_DPIPE_TABLE_RESOURCE_UNITS = 76 /* u64 */
-	DEVLINK_ATTR_PORT_FLAVOUR               = 77
-	DEVLINK_ATTR_PARAM                      = 80  /* nested */
-	DEVLINK_ATTR_PARAM_NAME                 = 81  /* string */
-	DEVLINK_ATTR_PARAM_GENERIC              = 82  /* flag */
-	DEVLINK_ATTR_PARAM_TYPE                 = 83  /* u8 */
-	DEVLINK_ATTR_PARAM_VALUES_LIST          = 84  /* nested */
-	DEVLINK_ATTR_PARAM_VALUE                = 85  /* nested */
-	DEVLINK_ATTR_PARAM_VALUE_DATA           = 86  /* dynamic */
-	DEVLINK_ATTR_PARAM_VALUE_CMODE          = 87  /* u8 */
-	DEVLINK_ATTR_PORT_PCI_PF_NUMBER         = 127 /* u16 */
-	DEVLINK_ATTR_PORT_FUNCTION              = 145 /* nested */
-	DEVLINK_ATTR_PORT_CONTROLLER_NUMBER     = 150 /* u32 */
-	DEVLINK_ATTR_PORT_PCI_SF_NUMBER         = 164 /* u32 */
-	DEVLINK_ATTR_EXT_PORT_FN_CAP            = 8193
-	DEVLINK_ATTR_MAX                        = 8201
+	DEVLINK_GENL_MCGRP_CONFIG_NAME = "config"
+)
+
+const (
+	DEVLINK_CMD_GET                        = 1
+	DEVLINK_CMD_PORT_GET                   = 5
+	DEVLINK_CMD_PORT_SET                   = 6
+	DEVLINK_CMD_PORT_NEW                   = 7
+	DEVLINK_CMD_PORT_DEL                   = 8
+	DEVLINK_CMD_PORT_SPLIT                 = 9
+	DEVLINK_CMD_PORT_UNSPLIT               = 10
+	DEVLINK_CMD_SB_GET                     = 11
+	DEVLINK_CMD_SB_POOL_GET                = 15
+	DEVLINK_CMD_SB_POOL_SET                = 16
+	DEVLINK_CMD_SB_PORT_POOL_GET           = 19
+	DEVLINK_CMD_SB_PORT_POOL_SET           = 20
+	DEVLINK_CMD_SB_TC_POOL_BIND_GET        = 23
+	DEVLINK_CMD_SB_TC_POOL_BIND_SET        = 24
+	DEVLINK_CMD_SB_OCC_SNAPSHOT            = 27
+	DEVLINK_CMD_SB_OCC_MAX_CLEAR           = 28
+	DEVLINK_CMD_ESWITCH_GET                = 29
+	DEVLINK_CMD_ESWITCH_SET                = 30
+	DEVLINK_CMD_DPIPE_TABLE_GET            = 31
+	DEVLINK_CMD_DPIPE_ENTRIES_GET          = 32
+	DEVLINK_CMD_DPIPE_HEADERS_GET          = 33
+	DEVLINK_CMD_DPIPE_TABLE_COUNTERS_SET   = 34
+	DEVLINK_CMD_RESOURCE_SET               = 35
+	DEVLINK_CMD_RESOURCE_DUMP              = 36
+	DEVLINK_CMD_RELOAD                     = 37
+	DEVLINK_CMD_PARAM_GET                  = 38
+	DEVLINK_CMD_PARAM_SET                  = 39
+	DEVLINK_CMD_REGION_GET                 = 42
+	DEVLINK_CMD_REGION_SET                 = 43
+	DEVLINK_CMD_REGION_NEW                 = 44
+	DEVLINK_CMD_REGION_DEL                 = 45
+	DEVLINK_CMD_REGION_READ                = 46
+	DEVLINK_CMD_PORT_PARAM_GET             = 47
+	DEVLINK_CMD_PORT_PARAM_SET             = 48
+	DEVLINK_CMD_INFO_GET                   = 51
+	DEVLINK_CMD_HEALTH_REPORTER_GET        = 52
+	DEVLINK_CMD_HEALTH_REPORTER_SET        = 53
+	DEVLINK_CMD_HEALTH_REPORTER_RECOVER    = 54
+	DEVLINK_CMD_HEALTH_REPORTER_DIAGNOSE   = 55
+	DEVLINK_CMD_HEALTH_REPORTER_DUMP_GET   = 56
+	DEVLINK_CMD_HEALTH_REPORTER_DUMP_CLEAR = 57
+	DEVLINK_CMD_FLASH_UPDATE               = 58
+	DEVLINK_CMD_FLASH_UPDATE_END           = 59 /* notification only */
+	DEVLINK_CMD_FLASH_UPDATE_STATUS        = 60 /* notification only */
+	DEVLINK_CMD_TRAP_GET                   = 61
+	DEVLINK_CMD_TRAP_SET                   = 62
+	DEVLINK_CMD_TRAP_NEW                   = 63
+	DEVLINK_CMD_TRAP_DEL                   = 64
+	DEVLINK_CMD_TRAP_GROUP_GET             = 65
+	DEVLINK_CMD_TRAP_GROUP_SET             = 66
+	DEVLINK_CMD_TRAP_GROUP_NEW             = 67
+	DEVLINK_CMD_TRAP_GROUP_DEL             = 68
+	DEVLINK_CMD_TRAP_POLICER_GET           = 69
+	DEVLINK_CMD_TRAP_POLICER_SET           = 70
+	DEVLINK_CMD_TRAP_POLICER_NEW           = 71
+	DEVLINK_CMD_TRAP_POLICER_DEL           = 72
+	DEVLINK_CMD_HEALTH_REPORTER_TEST       = 73
+	DEVLINK_CMD_RATE_GET                   = 74
+	DEVLINK_CMD_RATE_SET                   = 75
+	DEVLINK_CMD_RATE_NEW                   = 76
+	DEVLINK_CMD_RATE_DEL                   = 77
+	DEVLINK_CMD_LINECARD_GET               = 78
+	DEVLINK_CMD_LINECARD_SET               = 79
+	DEVLINK_CMD_LINECARD_NEW               = 80
+	DEVLINK_CMD_LINECARD_DEL               = 81
+	DEVLINK_CMD_SELFTESTS_GET              = 82
+	DEVLINK_CMD_SELFTESTS_RUN              = 83
+	DEVLINK_CMD_EXT_CAP_SET                = 161
+	// commands supported by generic NL 'mlxdevm'
+	DEVLINK_CMD_EXT_RATE_GET = 162
+	DEVLINK_CMD_EXT_RATE_SET = 163
+	DEVLINK_CMD_EXT_RATE_NEW = 164
+	DEVLINK_CMD_EXT_RATE_DEL = 165
+)
+
+const (
+	DEVLINK_ATTR_BUS_NAME                        = 1
+	DEVLINK_ATTR_DEV_NAME                        = 2
+	DEVLINK_ATTR_PORT_INDEX                      = 3
+	DEVLINK_ATTR_PORT_TYPE                       = 4
+	DEVLINK_ATTR_PORT_NETDEV_IFINDEX             = 6
+	DEVLINK_ATTR_PORT_NETDEV_NAME                = 7
+	DEVLINK_ATTR_PORT_IBDEV_NAME                 = 8
+	DEVLINK_ATTR_PORT_SPLIT_COUNT                = 9  /* u32 */
+	DEVLINK_ATTR_PORT_SPLIT_GROUP                = 10 /* u32 */
+	DEVLINK_ATTR_SB_INDEX                        = 11 /* u32 */
+	DEVLINK_ATTR_SB_SIZE                         = 12 /* u32 */
+	DEVLINK_ATTR_SB_INGRESS_POOL_COUNT           = 13 /* u16 */
+	DEVLINK_ATTR_SB_EGRESS_POOL_COUNT            = 14 /* u16 */
+	DEVLINK_ATTR_SB_INGRESS_TC_COUNT             = 15 /* u16 */
+	DEVLINK_ATTR_SB_EGRESS_TC_COUNT              = 16 /* u16 */
+	DEVLINK_ATTR_SB_POOL_INDEX                   = 17 /* u16 */
+	DEVLINK_ATTR_SB_POOL_TYPE                    = 18 /* u8 */
+	DEVLINK_ATTR_SB_POOL_SIZE                    = 19 /* u32 */
+	DEVLINK_ATTR_SB_POOL_THRESHOLD_TYPE          = 20 /* u8 */
+	DEVLINK_ATTR_SB_THRESHOLD                    = 21 /* u32 */
+	DEVLINK_ATTR_SB_TC_INDEX                     = 22 /* u16 */
+	DEVLINK_ATTR_SB_OCC_CUR                      = 23 /* u32 */
+	DEVLINK_ATTR_SB_OCC_MAX                      = 24 /* u32 */
+	DEVLINK_ATTR_ESWITCH_MODE                    = 25
+	DEVLINK_ATTR_ESWITCH_INLINE_MODE             = 26
+	DEVLINK_ATTR_DPIPE_TABLES                    = 27 /* nested */
+	DEVLINK_ATTR_DPIPE_TABLE                     = 28 /* nested */
+	DEVLINK_ATTR_DPIPE_TABLE_NAME                = 29 /* string */
+	DEVLINK_ATTR_DPIPE_TABLE_SIZE                = 30 /* u64 */
+	DEVLINK_ATTR_DPIPE_TABLE_MATCHES             = 31 /* nested */
+	DEVLINK_ATTR_DPIPE_TABLE_ACTIONS             = 32 /* nested */
+	DEVLINK_ATTR_DPIPE_TABLE_COUNTERS_ENABLED    = 33 /* u8 */
+	DEVLINK_ATTR_DPIPE_ENTRIES                   = 34 /* nested */
+	DEVLINK_ATTR_DPIPE_ENTRY                     = 35 /* nested */
+	DEVLINK_ATTR_DPIPE_ENTRY_INDEX               = 36 /* u64 */
+	DEVLINK_ATTR_DPIPE_ENTRY_MATCH_VALUES        = 37 /* nested */
+	DEVLINK_ATTR_DPIPE_ENTRY_ACTION_VALUES       = 38 /* nested */
+	DEVLINK_ATTR_DPIPE_ENTRY_COUNTER             = 39 /* u64 */
+	DEVLINK_ATTR_DPIPE_MATCH                     = 40 /* nested */
+	DEVLINK_ATTR_DPIPE_MATCH_VALUE               = 41 /* nested */
+	DEVLINK_ATTR_DPIPE_MATCH_TYPE                = 42 /* u32 */
+	DEVLINK_ATTR_DPIPE_ACTION                    = 43 /* nested */
+	DEVLINK_ATTR_DPIPE_ACTION_VALUE              = 44 /* nested */
+	DEVLINK_ATTR_DPIPE_ACTION_TYPE               = 45 /* u32 */
+	DEVLINK_ATTR_DPIPE_VALUE                     = 46 /* binary */
+	DEVLINK_ATTR_DPIPE_VALUE_MASK                = 47 /* binary */
+	DEVLINK_ATTR_DPIPE_VALUE_MAPPING             = 48 /* u32 */
+	DEVLINK_ATTR_DPIPE_HEADERS                   = 49 /* nested */
+	DEVLINK_ATTR_DPIPE_HEADER                    = 50 /* nested */
+	DEVLINK_ATTR_DPIPE_HEADER_NAME               = 51 /* string */
+	DEVLINK_ATTR_DPIPE_HEADER_ID                 = 52 /* u32 */
+	DEVLINK_ATTR_DPIPE_HEADER_FIELDS             = 53 /* nested */
+	DEVLINK_ATTR_DPIPE_HEADER_GLOBAL             = 54 /* u8 */
+	DEVLINK_ATTR_DPIPE_HEADER_INDEX              = 55 /* u32 */
+	DEVLINK_ATTR_DPIPE_FIELD                     = 56 /* nested */
+	DEVLINK_ATTR_DPIPE_FIELD_NAME                = 57 /* string */
+	DEVLINK_ATTR_DPIPE_FIELD_ID                  = 58 /* u32 */
+	DEVLINK_ATTR_DPIPE_FIELD_BITWIDTH            = 59 /* u32 */
+	DEVLINK_ATTR_DPIPE_FIELD_MAPPING_TYPE        = 60 /* u32 */
+	DEVLINK_ATTR_ESWITCH_ENCAP_MODE              = 62
+	DEVLINK_ATTR_RESOURCE_LIST                   = 63 /* nested */
+	DEVLINK_ATTR_RESOURCE                        = 64 /* nested */
+	DEVLINK_ATTR_RESOURCE_NAME                   = 65 /* string */
+	DEVLINK_ATTR_RESOURCE_ID                     = 66 /* u64 */
+	DEVLINK_ATTR_RESOURCE_SIZE                   = 67 /* u64 */
+	DEVLINK_ATTR_RESOURCE_SIZE_NEW               = 68 /* u64 */
+	DEVLINK_ATTR_RESOURCE_SIZE_VALID             = 69 /* u8 */
+	DEVLINK_ATTR_RESOURCE_SIZE_MIN               = 70 /* u64 */
+	DEVLINK_ATTR_RESOURCE_SIZE_MAX               = 71 /* u64 */
+	DEVLINK_ATTR_RESOURCE_SIZE_GRAN              = 72 /* u64 */
+	DEVLINK_ATTR_RESOURCE_UNIT                   = 73 /* u8 */
+	DEVLINK_ATTR_RESOURCE_OCC                    = 74 /* u64 */
+	DEVLINK_ATTR_DPIPE_TABLE_RESOURCE_ID         = 75 /* u64 */
+	DEVLINK_ATTR_DPIPE_TABLE_RESOURCE_UNITS      = 76 /* u64 */
+	DEVLINK_ATTR_PORT_FLAVOUR                    = 77
+	DEVLINK_ATTR_PORT_NUMBER                     = 78  /* u32 */
+	DEVLINK_ATTR_PORT_SPLIT_SUBPORT_NUMBER       = 79  /* u32 */
+	DEVLINK_ATTR_PARAM                           = 80  /* nested */
+	DEVLINK_ATTR_PARAM_NAME                      = 81  /* string */
+	DEVLINK_ATTR_PARAM_GENERIC                   = 82  /* flag */
+	DEVLINK_ATTR_PARAM_TYPE                      = 83  /* u8 */
+	DEVLINK_ATTR_PARAM_VALUES_LIST               = 84  /* nested */
+	DEVLINK_ATTR_PARAM_VALUE                     = 85  /* nested */
+	DEVLINK_ATTR_PARAM_VALUE_DATA                = 86  /* dynamic */
+	DEVLINK_ATTR_PARAM_VALUE_CMODE               = 87  /* u8 */
+	DEVLINK_ATTR_REGION_NAME                     = 88  /* string */
+	DEVLINK_ATTR_REGION_SIZE                     = 89  /* u64 */
+	DEVLINK_ATTR_REGION_SNAPSHOTS                = 90  /* nested */
+	DEVLINK_ATTR_REGION_SNAPSHOT                 = 91  /* nested */
+	DEVLINK_ATTR_REGION_SNAPSHOT_ID              = 92  /* u32 */
+	DEVLINK_ATTR_REGION_CHUNKS                   = 93  /* nested */
+	DEVLINK_ATTR_REGION_CHUNK                    = 94  /* nested */
+	DEVLINK_ATTR_REGION_CHUNK_DATA               = 95  /* binary */
+	DEVLINK_ATTR_REGION_CHUNK_ADDR               = 96  /* u64 */
+	DEVLINK_ATTR_REGION_CHUNK_LEN                = 97  /* u64 */
+	DEVLINK_ATTR_INFO_DRIVER_NAME                = 98  /* string */
+	DEVLINK_ATTR_INFO_SERIAL_NUMBER              = 99  /* string */
+	DEVLINK_ATTR_INFO_VERSION_FIXED              = 100 /* nested */
+	DEVLINK_ATTR_INFO_VERSION_RUNNING            = 101 /* nested */
+	DEVLINK_ATTR_INFO_VERSION_STORED             = 102 /* nested */
+	DEVLINK_ATTR_INFO_VERSION_NAME               = 103 /* string */
+	DEVLINK_ATTR_INFO_VERSION_VALUE              = 104 /* string */
+	DEVLINK_ATTR_SB_POOL_CELL_SIZE               = 105 /* u32 */
+	DEVLINK_ATTR_FMSG                            = 106 /* nested */
+	DEVLINK_ATTR_FMSG_OBJ_NEST_START             = 107 /* flag */
+	DEVLINK_ATTR_FMSG_PAIR_NEST_START            = 108 /* flag */
+	DEVLINK_ATTR_FMSG_ARR_NEST_START             = 109 /* flag */
+	DEVLINK_ATTR_FMSG_NEST_END                   = 110 /* flag */
+	DEVLINK_ATTR_FMSG_OBJ_NAME                   = 111 /* string */
+	DEVLINK_ATTR_FMSG_OBJ_VALUE_TYPE             = 112 /* u8 */
+	DEVLINK_ATTR_FMSG_OBJ_VALUE_DATA             = 113 /* dynamic */
+	DEVLINK_ATTR_HEALTH_REPORTER                 = 114 /* nested */
+	DEVLINK_ATTR_HEALTH_REPORTER_NAME            = 115 /* string */
+	DEVLINK_ATTR_HEALTH_REPORTER_STATE           = 116 /* u8 */
+	DEVLINK_ATTR_HEALTH_REPORTER_ERR_COUNT       = 117 /* u64 */
+	DEVLINK_ATTR_HEALTH_REPORTER_RECOVER_COUNT   = 118 /* u64 */
+	DEVLINK_ATTR_HEALTH_REPORTER_DUMP_TS         = 119 /* u64 */
+	DEVLINK_ATTR_HEALTH_REPORTER_GRACEFUL_PERIOD = 120 /* u64 */
+	DEVLINK_ATTR_HEALTH_REPORTER_AUTO_RECOVER    = 121 /* u8 */
+	DEVLINK_ATTR_FLASH_UPDATE_FILE_NAME          = 122 /* string */
+	DEVLINK_ATTR_FLASH_UPDATE_COMPONENT          = 123 /* string */
+	DEVLINK_ATTR_FLASH_UPDATE_STATUS_MSG         = 124 /* string */
+	DEVLINK_ATTR_FLASH_UPDATE_STATUS_DONE        = 125 /* u64 */
+	DEVLINK_ATTR_FLASH_UPDATE_STATUS_TOTAL       = 126 /* u64 */
+	DEVLINK_ATTR_PORT_PCI_PF_NUMBER              = 127 /* u16 */
+	DEVLINK_ATTR_PORT_PCI_VF_NUMBER              = 128 /* u16 */
+	DEVLINK_ATTR_STATS                           = 129 /* nested */
+	DEVLINK_ATTR_TRAP_NAME                       = 130 /* string */
+	DEVLINK_ATTR_TRAP_ACTION                     = 131 /* u8 */
+	DEVLINK_ATTR_TRAP_TYPE                       = 132 /* u8 */
+	DEVLINK_ATTR_TRAP_GENERIC                    = 133 /* flag */
+	DEVLINK_ATTR_TRAP_METADATA                   = 134 /* nested */
+	DEVLINK_ATTR_TRAP_GROUP_NAME                 = 135 /* string */
+	DEVLINK_ATTR_RELOAD_FAILED                   = 136 /* u8 */
+	DEVLINK_ATTR_HEALTH_REPORTER_DUMP_TS_NS      = 137 /* u64 */
+	DEVLINK_ATTR_NETNS_FD                        = 138 /* u32 */
+	DEVLINK_ATTR_NETNS_PID                       = 139 /* u32 */
+	DEVLINK_ATTR_NETNS_ID                        = 140 /* u32 */
+	DEVLINK_ATTR_HEALTH_REPORTER_AUTO_DUMP       = 141 /* u8 */
+	DEVLINK_ATTR_TRAP_POLICER_ID                 = 142 /* u32 */
+	DEVLINK_ATTR_TRAP_POLICER_RATE               = 143 /* u64 */
+	DEVLINK_ATTR_TRAP_POLICER_BURST              = 144 /* u64 */
+	DEVLINK_ATTR_PORT_FUNCTION                   = 145 /* nested */
+	DEVLINK_ATTR_INFO_BOARD_SERIAL_NUMBER        = 146 /* string */
+	DEVLINK_ATTR_PORT_LANES                      = 147 /* u32 */
+	DEVLINK_ATTR_PORT_SPLITTABLE                 = 148 /* u8 */
+	DEVLINK_ATTR_PORT_EXTERNAL                   = 149 /* u8 */
+	DEVLINK_ATTR_PORT_CONTROLLER_NUMBER          = 150 /* u32 */
+	DEVLINK_ATTR_FLASH_UPDATE_STATUS_TIMEOUT     = 151 /* u64 */
+	DEVLINK_ATTR_FLASH_UPDATE_OVERWRITE_MASK     = 152 /* bitfield32 */
+	DEVLINK_ATTR_RELOAD_ACTION                   = 153 /* u8 */
+	DEVLINK_ATTR_RELOAD_ACTIONS_PERFORMED        = 154 /* bitfield32 */
+	DEVLINK_ATTR_RELOAD_LIMITS                   = 155 /* bitfield32 */
+	DEVLINK_ATTR_DEV_STATS                       = 156 /* nested */
+	DEVLINK_ATTR_RELOAD_STATS                    = 157 /* nested */
+	DEVLINK_ATTR_RELOAD_STATS_ENTRY              = 158 /* nested */
+	DEVLINK_ATTR_RELOAD_STATS_LIMIT              = 159 /* u8 */
+	DEVLINK_ATTR_RELOAD_STATS_VALUE              = 160 /* u32 */
+	DEVLINK_ATTR_REMOTE_RELOAD_STATS             = 161 /* nested */
+	DEVLINK_ATTR_RELOAD_ACTION_INFO              = 162 /* nested */
+	DEVLINK_ATTR_RELOAD_ACTION_STATS             = 163 /* nested */
+	DEVLINK_ATTR_PORT_PCI_SF_NUMBER              = 164 /* u32 */
+	DEVLINK_ATTR_RATE_TYPE                       = 165 /* u16 */
+	DEVLINK_ATTR_RATE_TX_SHARE                   = 166 /* u64 */
+	DEVLINK_ATTR_RATE_TX_MAX                     = 167 /* u64 */
+	DEVLINK_ATTR_RATE_NODE_NAME                  = 168 /* string */
+	DEVLINK_ATTR_RATE_PARENT_NODE_NAME           = 169 /* string */
+	DEVLINK_ATTR_REGION_MAX_SNAPSHOTS            = 170 /* u32 */
+	DEVLINK_ATTR_LINECARD_INDEX                  = 171 /* u32 */
+	DEVLINK_ATTR_LINECARD_STATE                  = 172 /* u8 */
+	DEVLINK_ATTR_LINECARD_TYPE                   = 173 /* string */
+	DEVLINK_ATTR_LINECARD_SUPPORTED_TYPES        = 174 /* nested */
+	DEVLINK_ATTR_NESTED_DEVLINK                  = 175 /* nested */
+	DEVLINK_ATTR_SELFTESTS                       = 176 /* nested */
+	DEVLINK_ATTR_RATE_TX_PRIORITY                = 177 /* u32 */
+	DEVLINK_ATTR_RATE_TX_WEIGHT                  = 178 /* u32 */
+	DEVLINK_ATTR_REGION_DIRECT                   = 179 /* flag */
+	DEVLINK_ATTR_EXT_PORT_FN_CAP                 = 8193
+	// attributes supported by generic NL 'mlxdevm'
+	DEVLINK_ATTR_EXT_RATE_TYPE             = 8194 /* u16 */
+	DEVLINK_ATTR_EXT_RATE_TX_SHARE         = 8195 /* u64 */
+	DEVLINK_ATTR_EXT_RATE_TX_MAX           = 8196 /* u64 */
+	DEVLINK_ATTR_EXT_RATE_NODE_NAME        = 8197 /* string */
+	DEVLINK_ATTR_EXT_RATE_PARENT_NODE_NAME = 8198 /* string */
+	DEVLINK_ATTR_MAX                       = 8201
 )
 
 const (
@@ -104,10 +316,20 @@ const (
 	DEVLINK_PORT_FUNCTION_ATTR_HW_ADDR = 1
 	DEVLINK_PORT_FN_ATTR_STATE         = 2
 	DEVLINK_PORT_FN_ATTR_OPSTATE       = 3
+	DEVLINK_PORT_FN_ATTR_CAPS          = 4 /* bitfield32 */
+	DEVLINK_PORT_FN_ATTR_DEVLINK       = 5 /* nested */
+	DEVLINK_PORT_FN_ATTR_MAX_IO_EQS    = 6 /* u32 */
 	// attributes supported by genric NL 'mlxdevm'
 	MLXDEVM_PORT_FN_ATTR_TRUST = 4
 )
 
+const (
+	DEVLINK_PORT_FN_CAP_ROCE         = 1 << 0
+	DEVLINK_PORT_FN_CAP_MIGRATABLE   = 1 << 1
+	DEVLINK_PORT_FN_CAP_IPSEC_CRYPTO = 1 << 2
+	DEVLINK_PORT_FN_CAP_IPSEC_PACKET = 1 << 3
+)
+
 const (
 	DEVLINK_PORT_FN_STATE_INACTIVE = 0
 	DEVLINK_PORT_FN_STATE_ACTIVE   = 1
@@ -123,9 +345,109 @@ const (
 	DEVLINK_PORT_FN_ATTR_EXT_CAP_UC_LIST = 162
 )
 
+const (
+	DEVLINK_RELOAD_ACTION_UNSPEC        = 0
+	DEVLINK_RELOAD_ACTION_DRIVER_REINIT = 1
+	DEVLINK_RELOAD_ACTION_FW_ACTIVATE   = 2
+)
+
+const (
+	DEVLINK_RELOAD_LIMIT_UNSPEC   = 0
+	DEVLINK_RELOAD_LIMIT_NO_RESET = 1
+	DEVLINK_RELOAD_LIMIT_MAX      = DEVLINK_RELOAD_LIMIT_NO_RESET
+)
+
+const (
+	DEVLINK_FLASH_OVERWRITE_SETTINGS    = 1 << 0
+	DEVLINK_FLASH_OVERWRITE_IDENTIFIERS = 1 << 1
+)
+
+const (
+	DEVLINK_HEALTH_REPORTER_STATE_HEALTHY = 0
+	DEVLINK_HEALTH_REPORTER_STATE_ERROR   = 1
+)
+
+const (
+	DEVLINK_RATE_TYPE_LEAF = 0
+	DEVLINK_RATE_TYPE_NODE = 1
+)
+
+const (
+	DEVLINK_ATTR_STATS_RX_PACKETS = 0 /* u64 */
+	DEVLINK_ATTR_STATS_RX_BYTES   = 1 /* u64 */
+	DEVLINK_ATTR_STATS_RX_DROPPED = 2 /* u64 */
+)
+
+const (
+	DEVLINK_TRAP_ACTION_DROP   = 0
+	DEVLINK_TRAP_ACTION_TRAP   = 1
+	DEVLINK_TRAP_ACTION_MIRROR = 2
+)
+
+const (
+	DEVLINK_TRAP_TYPE_DROP      = 0
+	DEVLINK_TRAP_TYPE_EXCEPTION = 1
+	DEVLINK_TRAP_TYPE_CONTROL   = 2
+)
+
+const (
+	DEVLINK_ATTR_TRAP_METADATA_TYPE_IN_PORT   = 0 /* flag */
+	DEVLINK_ATTR_TRAP_METADATA_TYPE_FA_COOKIE = 1 /* flag */
+)
+
+const (
+	DEVLINK_SB_POOL_TYPE_INGRESS = 0
+	DEVLINK_SB_POOL_TYPE_EGRESS  = 1
+)
+
+const (
+	DEVLINK_SB_THRESHOLD_TYPE_STATIC  = 0
+	DEVLINK_SB_THRESHOLD_TYPE_DYNAMIC = 1
+)
+
+const (
+	DEVLINK_LINECARD_STATE_UNSPEC              = 0
+	DEVLINK_LINECARD_STATE_UNPROVISIONED       = 1
+	DEVLINK_LINECARD_STATE_UNPROVISIONING      = 2
+	DEVLINK_LINECARD_STATE_PROVISIONING        = 3
+	DEVLINK_LINECARD_STATE_PROVISIONING_FAILED = 4
+	DEVLINK_LINECARD_STATE_PROVISIONED         = 5
+	DEVLINK_LINECARD_STATE_ACTIVE              = 6
+)
+
+const (
+	DEVLINK_ATTR_SELFTEST_ID_FLASH = 1 /* flag */
+)
+
+const (
+	DEVLINK_ATTR_SELFTEST_RESULT        = 1 /* nested */
+	DEVLINK_ATTR_SELFTEST_RESULT_ID     = 2 /* u32 */
+	DEVLINK_ATTR_SELFTEST_RESULT_STATUS = 3 /* u8 */
+)
+
+const (
+	DEVLINK_SELFTEST_STATUS_SKIP = 0
+	DEVLINK_SELFTEST_STATUS_PASS = 1
+	DEVLINK_SELFTEST_STATUS_FAIL = 2
+)
+
+const (
+	DEVLINK_DPIPE_MATCH_TYPE_FIELD_EXACT = 0
+)
+
+const (
+	DEVLINK_DPIPE_ACTION_TYPE_FIELD_MODIFY = 0
+)
+
+const (
+	DEVLINK_DPIPE_FIELD_MAPPING_TYPE_NONE    = 0
+	DEVLINK_DPIPE_FIELD_MAPPING_TYPE_IFINDEX = 1
+)
+
 const (
 	DEVLINK_PARAM_CMODE_RUNTIME    = 0
 	DEVLINK_PARAM_CMODE_DRIVERINIT = 1
+	DEVLINK_PARAM_CMODE_PERMANENT  = 2
 )
 
 const (